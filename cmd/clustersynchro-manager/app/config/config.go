@@ -1,6 +1,7 @@
 package config
 
 import (
+	"k8s.io/client-go/dynamic"
 	clientset "k8s.io/client-go/kubernetes"
 	restclient "k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/record"
@@ -8,15 +9,20 @@ import (
 
 	crdclientset "github.com/clusterpedia-io/clusterpedia/pkg/generated/clientset/versioned"
 	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+	"github.com/clusterpedia-io/clusterpedia/pkg/synchromanager"
 )
 
 type Config struct {
 	Client        *clientset.Clientset
 	Kubeconfig    *restclient.Config
 	CRDClient     *crdclientset.Clientset
+	DynamicClient dynamic.Interface
 	EventRecorder record.EventRecorder
 
 	StorageFactory storage.StorageFactory
+	ManagerOptions synchromanager.Options
+
+	MetricsBindAddress string
 
 	LeaderElection   componentbaseconfig.LeaderElectionConfiguration
 	ClientConnection componentbaseconfig.ClientConnectionConfiguration
@@ -6,6 +6,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/dynamic"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
@@ -23,6 +24,7 @@ import (
 	crdclientset "github.com/clusterpedia-io/clusterpedia/pkg/generated/clientset/versioned"
 	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
 	storageoptions "github.com/clusterpedia-io/clusterpedia/pkg/storage/options"
+	"github.com/clusterpedia-io/clusterpedia/pkg/synchromanager"
 )
 
 const (
@@ -35,9 +37,12 @@ type Options struct {
 
 	Logs    *logs.Options
 	Storage *storageoptions.StorageOptions
+	Manager synchromanager.Options
 
 	Master     string
 	Kubeconfig string
+
+	MetricsBindAddress string
 }
 
 func NewClusterSynchroManagerOptions() (*Options, error) {
@@ -66,6 +71,8 @@ func NewClusterSynchroManagerOptions() (*Options, error) {
 
 	options.Logs = logs.NewOptions()
 	options.Storage = storageoptions.NewStorageOptions()
+	options.Manager = synchromanager.NewOptions()
+	options.MetricsBindAddress = ":8080"
 	return &options, nil
 }
 
@@ -82,6 +89,32 @@ func (o *Options) Flags() cliflag.NamedFlagSets {
 	fs := fss.FlagSet("misc")
 	fs.StringVar(&o.Master, "master", o.Master, "The address of the Kubernetes API server (overrides any value in kubeconfig).")
 	fs.StringVar(&o.Kubeconfig, "kubeconfig", o.Kubeconfig, "Path to kubeconfig file with authorization and master location information.")
+	fs.StringVar(&o.MetricsBindAddress, "metrics-bind-address", o.MetricsBindAddress, "The address the metrics endpoint binds to, set it to empty to disable the metrics endpoint.")
+
+	synchrofs := fss.FlagSet("synchro")
+	synchrofs.StringVar(&o.Manager.ClusterSecretNamespace, "cluster-secret-namespace", o.Manager.ClusterSecretNamespace, "The namespace of the secrets referenced by the PediaClusters.")
+	synchrofs.DurationVar(&o.Manager.ShutdownTimeout, "shutdown-timeout", o.Manager.ShutdownTimeout, "How long to wait for the pending resources to be stored when the manager is stopped or loses the leadership, it should be less than the leader election lease duration.")
+	synchrofs.StringSliceVar(&o.Manager.AllowedExecCommands, "allowed-cluster-exec-commands", o.Manager.AllowedExecCommands, "The commands that can be used by the exec providers of the PediaClusters, the exec providers are rejected by default.")
+	synchrofs.StringSliceVar(&o.Manager.AllowedCredentialDirs, "allowed-cluster-credential-dirs", o.Manager.AllowedCredentialDirs, "The directories that the token, CA and certificate files of the PediaClusters can be in, the credential files are rejected by default.")
+	synchrofs.IntVar(&o.Manager.Synchro.BatchSize, "resource-batch-size", o.Manager.Synchro.BatchSize, "The max number of resources written to the storage in a batch.")
+	synchrofs.DurationVar(&o.Manager.Synchro.BatchFlushInterval, "resource-batch-flush-interval", o.Manager.Synchro.BatchFlushInterval, "How long to wait for more resources before writing a batch that is not full, 0 means writing immediately.")
+
+	synchrofs.Float32Var(&o.Manager.Synchro.ClientQPS, "cluster-client-qps", o.Manager.Synchro.ClientQPS, "The default QPS to use while talking with the member clusters, it is overridden by the rate limit of the PediaCluster.")
+	synchrofs.IntVar(&o.Manager.Synchro.ClientBurst, "cluster-client-burst", o.Manager.Synchro.ClientBurst, "The default burst to use while talking with the member clusters, it is overridden by the rate limit of the PediaCluster.")
+	synchrofs.IntVar(&o.Manager.Synchro.MaxConcurrentLists, "cluster-max-concurrent-lists", o.Manager.Synchro.MaxConcurrentLists, "The default max number of the concurrent list requests to a member cluster, 0 means no limit.")
+	synchrofs.DurationVar(&o.Manager.Synchro.PollingInterval, "cluster-polling-interval", o.Manager.Synchro.PollingInterval, "The default interval of relisting the resources that do not support watch, it is overridden by the list config of the PediaCluster.")
+	synchrofs.DurationVar(&o.Manager.Synchro.DiscoveryRefreshInterval, "cluster-discovery-refresh-interval", o.Manager.Synchro.DiscoveryRefreshInterval, "The interval of refreshing the discovery of the member clusters, it is also refreshed when the CRDs or the versions of the member clusters are changed.")
+	synchrofs.IntVar(&o.Manager.Synchro.HealthCheckFailureThreshold, "cluster-health-failure-threshold", o.Manager.Synchro.HealthCheckFailureThreshold, "The number of the consecutive failed health checks before the member cluster is considered as not ready.")
+	synchrofs.IntVar(&o.Manager.Synchro.HealthCheckStopThreshold, "cluster-health-stop-threshold", o.Manager.Synchro.HealthCheckStopThreshold, "The number of the consecutive failed health checks before the synchronization of the member cluster is stopped.")
+	synchrofs.DurationVar(&o.Manager.Synchro.ClockSkewThreshold, "cluster-clock-skew-threshold", o.Manager.Synchro.ClockSkewThreshold, "The max clock skew between the member cluster and the manager before it is reported in the Ready condition, 0 means no check.")
+	synchrofs.DurationVar(&o.Manager.Synchro.StatisticsPublishInterval, "resource-statistics-publish-interval", o.Manager.Synchro.StatisticsPublishInterval, "The interval of publishing the statistics of the synchronized resources in the status of the PediaClusters, the real-time statistics are exposed by the metrics.")
+
+	shardingfs := fss.FlagSet("sharding")
+	shardingfs.BoolVar(&o.Manager.Sharding.Enabled, "enable-sharding", o.Manager.Sharding.Enabled, "Enable the active-active sharding mode, the clusters are assigned to all replicas, and only the cluster import controller is run with the leader election.")
+	shardingfs.StringVar(&o.Manager.Sharding.Identity, "shard-identity", o.Manager.Sharding.Identity, "The unique identity of the replica, it is generated with the hostname if it is empty.")
+	shardingfs.StringVar(&o.Manager.Sharding.Namespace, "shard-namespace", o.Manager.Sharding.Namespace, "The namespace of the leases of the replicas.")
+	shardingfs.DurationVar(&o.Manager.Sharding.LeaseDuration, "shard-lease-duration", o.Manager.Sharding.LeaseDuration, "How long the replica is considered alive after its lease is renewed, the clusters of a dead replica are moved after the duration.")
+	shardingfs.DurationVar(&o.Manager.Sharding.RenewInterval, "shard-renew-interval", o.Manager.Sharding.RenewInterval, "The interval of renewing the lease of the replica and refreshing the alive replicas.")
 
 	o.Storage.AddFlags(fss.FlagSet("storage"))
 	o.Logs.AddFlags(fss.FlagSet("logs"))
@@ -93,6 +126,56 @@ func (o *Options) Validate() error {
 
 	errs = append(errs, o.Logs.Validate()...)
 	errs = append(errs, o.Storage.Validate()...)
+	if o.Manager.ClusterSecretNamespace == "" {
+		errs = append(errs, fmt.Errorf("--cluster-secret-namespace is required"))
+	}
+	if o.Manager.ShutdownTimeout < 0 {
+		errs = append(errs, fmt.Errorf("--shutdown-timeout can not be negative"))
+	}
+	if o.Manager.Sharding.Enabled {
+		if o.Manager.Sharding.Namespace == "" {
+			errs = append(errs, fmt.Errorf("--shard-namespace is required"))
+		}
+		if o.Manager.Sharding.RenewInterval <= 0 {
+			errs = append(errs, fmt.Errorf("--shard-renew-interval must be greater than 0"))
+		}
+		if o.Manager.Sharding.LeaseDuration <= o.Manager.Sharding.RenewInterval {
+			errs = append(errs, fmt.Errorf("--shard-lease-duration must be greater than --shard-renew-interval"))
+		}
+	}
+	if o.Manager.Synchro.ClientQPS <= 0 {
+		errs = append(errs, fmt.Errorf("--cluster-client-qps must be greater than 0"))
+	}
+	if o.Manager.Synchro.ClientBurst <= 0 {
+		errs = append(errs, fmt.Errorf("--cluster-client-burst must be greater than 0"))
+	}
+	if o.Manager.Synchro.MaxConcurrentLists < 0 {
+		errs = append(errs, fmt.Errorf("--cluster-max-concurrent-lists can not be negative"))
+	}
+	if o.Manager.Synchro.PollingInterval <= 0 {
+		errs = append(errs, fmt.Errorf("--cluster-polling-interval must be greater than 0"))
+	}
+	if o.Manager.Synchro.DiscoveryRefreshInterval <= 0 {
+		errs = append(errs, fmt.Errorf("--cluster-discovery-refresh-interval must be greater than 0"))
+	}
+	if o.Manager.Synchro.HealthCheckFailureThreshold <= 0 {
+		errs = append(errs, fmt.Errorf("--cluster-health-failure-threshold must be greater than 0"))
+	}
+	if o.Manager.Synchro.HealthCheckStopThreshold < o.Manager.Synchro.HealthCheckFailureThreshold {
+		errs = append(errs, fmt.Errorf("--cluster-health-stop-threshold can not be less than --cluster-health-failure-threshold"))
+	}
+	if o.Manager.Synchro.ClockSkewThreshold < 0 {
+		errs = append(errs, fmt.Errorf("--cluster-clock-skew-threshold can not be negative"))
+	}
+	if o.Manager.Synchro.StatisticsPublishInterval <= 0 {
+		errs = append(errs, fmt.Errorf("--resource-statistics-publish-interval must be greater than 0"))
+	}
+	if o.Manager.Synchro.BatchSize <= 0 {
+		errs = append(errs, fmt.Errorf("--resource-batch-size must be greater than 0"))
+	}
+	if o.Manager.Synchro.BatchFlushInterval < 0 {
+		errs = append(errs, fmt.Errorf("--resource-batch-flush-interval can not be negative"))
+	}
 	return utilerrors.NewAggregate(errs)
 }
 
@@ -124,6 +207,10 @@ func (o *Options) Config() (*config.Config, error) {
 	if err != nil {
 		return nil, err
 	}
+	dynamicclient, err := dynamic.NewForConfig(restclient.AddUserAgent(kubeconfig, ClusterSynchroManagerUserAgent))
+	if err != nil {
+		return nil, err
+	}
 
 	eventBroadcaster := record.NewBroadcaster()
 	eventBroadcaster.StartStructuredLogging(0)
@@ -134,10 +221,13 @@ func (o *Options) Config() (*config.Config, error) {
 	return &config.Config{
 		Client:         client,
 		CRDClient:      crdclient,
+		DynamicClient:  dynamicclient,
 		Kubeconfig:     kubeconfig,
 		EventRecorder:  eventRecorder,
 		StorageFactory: storagefactory,
+		ManagerOptions: o.Manager,
 
-		LeaderElection: o.LeaderElection,
+		MetricsBindAddress: o.MetricsBindAddress,
+		LeaderElection:     o.LeaderElection,
 	}, nil
 }
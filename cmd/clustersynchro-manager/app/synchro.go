@@ -3,20 +3,25 @@ package app
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/uuid"
 	restclient "k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/leaderelection"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	cliflag "k8s.io/component-base/cli/flag"
 	"k8s.io/component-base/cli/globalflag"
+	"k8s.io/component-base/metrics/legacyregistry"
 	"k8s.io/component-base/term"
 	"k8s.io/klog/v2"
 
 	"github.com/clusterpedia-io/clusterpedia/cmd/clustersynchro-manager/app/config"
 	"github.com/clusterpedia-io/clusterpedia/cmd/clustersynchro-manager/app/options"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
 	"github.com/clusterpedia-io/clusterpedia/pkg/synchromanager"
+	"github.com/clusterpedia-io/clusterpedia/pkg/synchromanager/clusterimport"
 	"github.com/clusterpedia-io/clusterpedia/pkg/version/verflag"
 )
 
@@ -64,9 +69,42 @@ func NewClusterSynchroManagerCommand(ctx context.Context) *cobra.Command {
 }
 
 func Run(ctx context.Context, c *config.Config) error {
-	synchromanager := synchromanager.NewManager(c.Client, c.CRDClient, c.StorageFactory)
+	if c.MetricsBindAddress != "" {
+		go serveMetrics(ctx, c.MetricsBindAddress)
+	}
+
+	synchromanager := synchromanager.NewManager(c.Client, c.CRDClient, c.StorageFactory, c.ManagerOptions)
+	importController := clusterimport.NewController(c.DynamicClient, c.CRDClient)
+	// the expired data of the storage is collected by the leader only
+	runGarbageCollection := func(stopCh <-chan struct{}) {}
+	if collector, ok := c.StorageFactory.(storage.GarbageCollector); ok {
+		runGarbageCollection = collector.RunGarbageCollection
+	}
+
+	run := func(stopCh <-chan struct{}) {
+		go runGarbageCollection(stopCh)
+		go importController.Run(1, stopCh)
+		synchromanager.Run(1, stopCh)
+	}
+
+	if c.ManagerOptions.Sharding.Enabled {
+		// every replica synchronizes its own shard of the clusters,
+		// only the cluster import controller and the garbage collection require the leader election.
+		managerDone := make(chan struct{})
+		go func() {
+			defer close(managerDone)
+			synchromanager.Run(1, ctx.Done())
+		}()
+		defer func() { <-managerDone }()
+
+		run = func(stopCh <-chan struct{}) {
+			go runGarbageCollection(stopCh)
+			importController.Run(1, stopCh)
+		}
+	}
+
 	if !c.LeaderElection.LeaderElect {
-		synchromanager.Run(1, ctx.Done())
+		run(ctx.Done())
 		return nil
 	}
 
@@ -74,15 +112,15 @@ func Run(ctx context.Context, c *config.Config) error {
 	if err != nil {
 		return err
 	}
-	id = "local"
-	//id += "_" + string(uuid.NewUUID())
+	// add a uniquifier so that two processes on the same host don't accidentally both become active
+	id += "_" + string(uuid.NewUUID())
 
 	rl, err := resourcelock.NewFromKubeconfig(
 		c.LeaderElection.ResourceLock,
 		c.LeaderElection.ResourceNamespace,
-		id,
+		c.LeaderElection.ResourceName,
 		resourcelock.ResourceLockConfig{
-			Identity:      c.LeaderElection.ResourceName,
+			Identity:      id,
 			EventRecorder: c.EventRecorder,
 		},
 		c.Kubeconfig,
@@ -107,7 +145,7 @@ func Run(ctx context.Context, c *config.Config) error {
 				defer close(done)
 
 				stopCh := ctx.Done()
-				synchromanager.Run(1, stopCh)
+				run(stopCh)
 			},
 			OnStoppedLeading: func() {
 				klog.Info("leaderelection lost")
@@ -119,3 +157,18 @@ func Run(ctx context.Context, c *config.Config) error {
 	})
 	return nil
 }
+
+func serveMetrics(ctx context.Context, address string) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", legacyregistry.Handler())
+	server := &http.Server{Addr: address, Handler: mux}
+	go func() {
+		<-ctx.Done()
+		server.Close()
+	}()
+
+	klog.InfoS("Serve metrics", "address", address)
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		klog.ErrorS(err, "Failed to serve metrics", "address", address)
+	}
+}
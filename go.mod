@@ -24,6 +24,7 @@ require (
 	k8s.io/kubernetes v1.22.4
 	sigs.k8s.io/controller-runtime v0.10.3
 	sigs.k8s.io/controller-tools v0.7.0
+	sigs.k8s.io/yaml v1.2.0
 )
 
 replace (
@@ -42,6 +42,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&PediaCluster{},
 		&PediaClusterList{},
+		&ClusterImportPolicy{},
+		&ClusterImportPolicyList{},
+		&ClusterSyncResources{},
+		&ClusterSyncResourcesList{},
 	)
 	// AddToGroupVersion allows the serialization of client types like ListOptions.
 	v1.AddToGroupVersion(scheme, SchemeGroupVersion)
@@ -10,6 +10,7 @@ const (
 	SyncStatusPending = "Pending"
 	SyncStatusSyncing = "Syncing"
 	SyncStatusStop    = "Stop"
+	SyncStatusPaused  = "Paused"
 )
 
 // +genclient
@@ -20,6 +21,7 @@ const (
 // +kubebuilder:printcolumn:name="APIServer URL",type=string,JSONPath=".spec.apiserverURL"
 // +kubebuilder:printcolumn:name="Version",type=string,JSONPath=".status.version"
 // +kubebuilder:printcolumn:name="Status",type=string,JSONPath=".status.conditions[?(@.type == 'Ready')].reason"
+// +kubebuilder:printcolumn:name="Shard",type=string,JSONPath=".status.shard",priority=1
 type PediaCluster struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -32,9 +34,16 @@ type PediaCluster struct {
 }
 
 type ClusterSpec struct {
-	// +required
-	// +kubebuilder:validation:Required
-	APIServerURL string `json:"apiserverURL"`
+	// APIServerURL is required if the secretRef does not contain a kubeconfig,
+	// it overrides the server of the kubeconfig if it is set.
+	// +optional
+	APIServerURL string `json:"apiserverURL,omitempty"`
+
+	// SecretRef references a secret in the cluster secret namespace of the clustersynchro manager,
+	// the secret contains a `kubeconfig` key, or the `token`, `ca.crt`, `tls.crt` and `tls.key` keys.
+	// The inline credentials are ignored if it is set.
+	// +optional
+	SecretRef *ClusterSecretReference `json:"secretRef,omitempty"`
 
 	// +optional
 	TokenData string `json:"tokenData,omitmepty"`
@@ -48,20 +57,219 @@ type ClusterSpec struct {
 	// +optional
 	KeyData string `json:"keyData,omitempty"`
 
+	// TokenFile is the path of the bearer token file on the clustersynchro manager,
+	// the token is reloaded periodically and takes precedence over the TokenData.
+	// The credential files must be in the directories allowed by the clustersynchro manager.
+	// +optional
+	TokenFile string `json:"tokenFile,omitempty"`
+
+	// CAFile is the path of the CA file on the clustersynchro manager
+	// +optional
+	CAFile string `json:"caFile,omitempty"`
+
+	// CertFile and KeyFile are the paths of the client certificate files on the clustersynchro manager,
+	// the files are reloaded when they are changed.
+	// +optional
+	CertFile string `json:"certFile,omitempty"`
+
+	// +optional
+	KeyFile string `json:"keyFile,omitempty"`
+
+	// ExecProvider is the exec-based credential plugin that provides the credentials of the cluster,
+	// the command must be allowed by the clustersynchro manager.
+	// +optional
+	ExecProvider *ExecConfig `json:"execProvider,omitempty"`
+
+	// ProxyURL is the URL of the proxy used to connect to the cluster,
+	// the supported schemes are http, https and socks5.
+	// +optional
+	ProxyURL string `json:"proxyURL,omitempty"`
+
+	// TLSServerName overrides the server name used to verify the certificate of the cluster
+	// +optional
+	TLSServerName string `json:"tlsServerName,omitempty"`
+
+	// RateLimit limits the requests to the cluster,
+	// the defaults of the clustersynchro manager are used if it is not set.
+	// +optional
+	RateLimit *ClusterRateLimit `json:"rateLimit,omitempty"`
+
+	// Resources are the resources to be synchronized,
+	// they take precedence over the same resources of the referenced ClusterSyncResources.
+	// +optional
+	Resources []ClusterResource `json:"resources,omitempty"`
+
+	// SyncResourcesRefName references a ClusterSyncResources whose resources are synchronized with the inline resources
+	// +optional
+	SyncResourcesRefName string `json:"syncResourcesRefName,omitempty"`
+
+	// SyncResourcesSelector selects the ClusterSyncResources by labels,
+	// the resources of the selected ClusterSyncResources are synchronized with the inline resources.
+	// +optional
+	SyncResourcesSelector *metav1.LabelSelector `json:"syncResourcesSelector,omitempty"`
+
+	// Paused stops the synchronization of all resources of the cluster,
+	// the synchronized resources are kept and the synchronization is resumed incrementally.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
+	// Prune is the default prune config for the resources of the cluster,
+	// it can be overridden by the prune config of the resource.
+	// +optional
+	Prune *PruneConfig `json:"prune,omitempty"`
+
+	// List configures how the resources are listed from the cluster
+	// +optional
+	List *ListConfig `json:"list,omitempty"`
+}
+
+// ClusterRateLimit limits the requests of the clients to the cluster
+type ClusterRateLimit struct {
+	// QPS is the max queries per second to the cluster
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	QPS int32 `json:"qps,omitempty"`
+
+	// Burst is the max burst of the queries to the cluster
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Burst int32 `json:"burst,omitempty"`
+
+	// MaxConcurrentLists is the max number of the concurrent list requests of the resource synchros
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxConcurrentLists int32 `json:"maxConcurrentLists,omitempty"`
+}
+
+// ListConfig configures the list requests of the resources
+type ListConfig struct {
+	// PageSize is the max number of resources in a list response,
+	// the resources are listed in pages with the limit and continue options if it is set.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	PageSize int64 `json:"pageSize,omitempty"`
+
+	// Streaming handles each page of the list directly instead of holding the whole list in memory,
+	// the next page is not listed until the resources of the previous page are stored.
+	// If PageSize is not set, the default page size 500 is used.
+	// +optional
+	Streaming bool `json:"streaming,omitempty"`
+
+	// PollingInterval is the interval of relisting the resources that do not support watch,
+	// the default of the clustersynchro manager is used if it is not set.
+	// +optional
+	PollingInterval *metav1.Duration `json:"pollingInterval,omitempty"`
+}
+
+// ExecConfig specifies a command to provide client credentials,
+// it is the same as the exec config of the kubeconfig.
+type ExecConfig struct {
+	// +required
+	// +kubebuilder:validation:Required
+	Command string `json:"command"`
+
+	// +optional
+	Args []string `json:"args,omitempty"`
+
+	// +optional
+	Env []ExecEnvVar `json:"env,omitempty"`
+
+	// APIVersion is the preferred input version of the ExecInfo
 	// +required
-	Resources []ClusterResource `json:"resources"`
+	// +kubebuilder:validation:Required
+	APIVersion string `json:"apiVersion"`
+
+	// +optional
+	ProvideClusterInfo bool `json:"provideClusterInfo,omitempty"`
+}
+
+type ExecEnvVar struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type ClusterSecretReference struct {
+	// +required
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Context is the context used in the kubeconfig, the current context is used by default
+	// +optional
+	Context string `json:"context,omitempty"`
 }
 
+const (
+	// ResourceWildcard selects all groups or all resources of the groups
+	ResourceWildcard = "*"
+)
+
 type ClusterResource struct {
+	// Group is the group of the resources, `*` selects all groups of the cluster
 	Group string `json:"group"`
 
+	// Versions are the versions of the custom resources, they are ignored if the group is `*`
 	// +optional
 	Versions []string `json:"versions,omitempty"`
 
+	// Resources are the resources of the group, `*` selects all resources of the group
+	// that are discovered from the cluster, the selected resources are reported in the cluster status.
 	// +required
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:MinItems=1
 	Resources []string `json:"resources"`
+
+	// ExcludeResources are the resources that are not synchronized,
+	// the `<resource>` excludes the resource of any group, and the `<resource>.<group>` excludes the resource of the group.
+	// +optional
+	ExcludeResources []string `json:"excludeResources,omitempty"`
+
+	// Namespaces limits the namespaces of the synchronized resources,
+	// it is ignored for cluster scoped resources.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// ExcludeNamespaces are the namespaces that are not synchronized,
+	// it is ignored for cluster scoped resources.
+	// +optional
+	ExcludeNamespaces []string `json:"excludeNamespaces,omitempty"`
+
+	// +optional
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+
+	// FieldSelector is passed to the list/watch requests of the member cluster, eg. `status.phase=Running`
+	// +optional
+	FieldSelector string `json:"fieldSelector,omitempty"`
+
+	// +optional
+	Prune *PruneConfig `json:"prune,omitempty"`
+
+	// MetadataOnly synchronizes and stores only the metadata of the resources,
+	// the stored resources are marked with the `shadow.clusterpedia.io/metadata-only` annotation.
+	// +optional
+	MetadataOnly bool `json:"metadataOnly,omitempty"`
+
+	// Paused stops the synchronization of the resources,
+	// the synchronized resources are kept and the synchronization is resumed incrementally.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+}
+
+// PruneConfig configures the fields that are pruned from the resources before they are stored
+type PruneConfig struct {
+	// +optional
+	ManagedFields bool `json:"managedFields,omitempty"`
+
+	// LastAppliedConfiguration prunes the `kubectl.kubernetes.io/last-applied-configuration` annotation
+	// +optional
+	LastAppliedConfiguration bool `json:"lastAppliedConfiguration,omitempty"`
+
+	// Annotations are the annotation keys to be pruned
+	// +optional
+	Annotations []string `json:"annotations,omitempty"`
+
+	// Paths are the dot-separated field paths to be pruned, eg. `status.images`
+	// +optional
+	Paths []string `json:"paths,omitempty"`
 }
 
 type ClusterStatus struct {
@@ -74,6 +282,11 @@ type ClusterStatus struct {
 
 	// +optional
 	Resources []ClusterGroupStatus `json:"resources,omitempty"`
+
+	// Shard is the identity of the clustersynchro-manager replica that synchronizes the cluster,
+	// it is set only in the sharding mode.
+	// +optional
+	Shard string `json:"shard,omitempty"`
 }
 
 type ClusterGroupStatus struct {
@@ -125,6 +338,39 @@ type ClusterResourceSyncCondition struct {
 	// optional
 	Message string `json:"message,omitempty"`
 
+	// FailedResourceCount is the number of resources that failed to be stored after retries
+	// +optional
+	FailedResourceCount int `json:"failedResourceCount,omitempty"`
+
+	// FailedResources are part of the keys of the resources that failed to be stored
+	// +optional
+	FailedResources []string `json:"failedResources,omitempty"`
+
+	// InitialListSynced is true after the initial list of the resource is written to the storage
+	// +optional
+	InitialListSynced bool `json:"initialListSynced,omitempty"`
+
+	// StoredResourceCount is the number of resources that are stored or pending to be stored
+	// +optional
+	StoredResourceCount int `json:"storedResourceCount,omitempty"`
+
+	// ProcessedEventCount is the number of the events that are handled
+	// +optional
+	ProcessedEventCount int64 `json:"processedEventCount,omitempty"`
+
+	// QueueDepth is the number of the resources waiting to be stored
+	// +optional
+	QueueDepth int `json:"queueDepth,omitempty"`
+
+	// +optional
+	LastEventTime *metav1.Time `json:"lastEventTime,omitempty"`
+
+	// +optional
+	LastWriteTime *metav1.Time `json:"lastWriteTime,omitempty"`
+
+	// +optional
+	LastStorageError string `json:"lastStorageError,omitempty"`
+
 	// +required
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:Type=string
@@ -143,3 +389,132 @@ type PediaClusterList struct {
 
 	Items []PediaCluster `json:"items"`
 }
+
+const (
+	ClusterImportPolicyConditionReady = "Ready"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope="Cluster"
+// +kubebuilder:printcolumn:name="Source",type=string,JSONPath=".spec.source.resource"
+// +kubebuilder:printcolumn:name="Imported",type=integer,JSONPath=".status.importedClusters"
+// +kubebuilder:printcolumn:name="Status",type=string,JSONPath=".status.conditions[?(@.type == 'Ready')].reason"
+type ClusterImportPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +optional
+	Spec ClusterImportPolicySpec `json:"spec,omitempty"`
+
+	// +optional
+	Status ClusterImportPolicyStatus `json:"status,omitempty"`
+}
+
+type ClusterImportPolicySpec struct {
+	// Source is the resource type of the objects that the PediaClusters are imported from
+	// +required
+	// +kubebuilder:validation:Required
+	Source ImportSource `json:"source"`
+
+	// NamePrefix is the name prefix of the imported PediaClusters,
+	// the name of the PediaCluster is `<prefix><name>` or `<prefix><namespace>-<name>` for namespaced source.
+	// +optional
+	NamePrefix string `json:"namePrefix,omitempty"`
+
+	// Template is a go template that is rendered to the yaml of the PediaCluster's spec,
+	// the source object is referenced by `.source`, eg. `apiserverURL: {{ .source.spec.apiEndpoint }}`.
+	// The functions `b64enc`, `b64dec` and `default` are available in the template.
+	// +required
+	// +kubebuilder:validation:Required
+	Template string `json:"template"`
+
+	// CreationCondition is a go template, the PediaCluster is created only if it is rendered to `true`.
+	// The PediaCluster is always created if it is empty.
+	// +optional
+	CreationCondition string `json:"creationCondition,omitempty"`
+
+	// DeletionCondition is a go template, the imported PediaCluster is deleted if it is rendered to `true`
+	// +optional
+	DeletionCondition string `json:"deletionCondition,omitempty"`
+
+	// Resources are the default resources to be synchronized if the rendered spec does not contain resources
+	// +optional
+	Resources []ClusterResource `json:"resources,omitempty"`
+}
+
+type ImportSource struct {
+	Group string `json:"group"`
+
+	// +required
+	// +kubebuilder:validation:Required
+	Version string `json:"version"`
+
+	// +required
+	// +kubebuilder:validation:Required
+	Resource string `json:"resource"`
+
+	// Namespace limits the namespace of the source objects, all namespaces by default
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// +optional
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+}
+
+type ClusterImportPolicyStatus struct {
+	// +optional
+	ImportedClusters int `json:"importedClusters,omitempty"`
+
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type ClusterImportPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ClusterImportPolicy `json:"items"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +resourceName=clustersyncresources
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:scope="Cluster"
+
+// ClusterSyncResources is a named set of the resources to be synchronized,
+// it is shared by the PediaClusters that reference it by name or by the label selector.
+type ClusterSyncResources struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +optional
+	Spec ClusterSyncResourcesSpec `json:"spec,omitempty"`
+}
+
+type ClusterSyncResourcesSpec struct {
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	SyncResources []ClusterResource `json:"syncResources"`
+}
+
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type ClusterSyncResourcesList struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ClusterSyncResources `json:"items"`
+}
@@ -24,6 +24,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&ListOptions{},
 		&CollectionResource{},
 		&CollectionResourceList{},
+		&ResourceRevision{},
+		&ResourceRevisionList{},
 	)
 	return nil
 }
@@ -17,11 +17,22 @@ const (
 	SearchLabelNamespaces = "search.clusterpedia.io/namespaces"
 	SearchLabelOrderBy    = "search.clusterpedia.io/orderby"
 
+	// SearchLabelWithDeleted includes the tombstones of the deleted resources in the list
+	SearchLabelWithDeleted = "search.clusterpedia.io/with-deleted"
+
 	SearchLabelSize   = "search.clusterpedia.io/size"
 	SearchLabelOffset = "search.clusterpedia.io/offset"
 
 	ShadowLabelClusterName          = "shadow.clusterpedia.io/cluster-name"
 	ShadowLabelGroupVersionResource = "shadow.clusterpedia.io/gvr"
+
+	ShadowAnnotationPrunedFields = "shadow.clusterpedia.io/pruned-fields"
+
+	// ShadowAnnotationMetadataOnly marks the resource that only the metadata is synchronized
+	ShadowAnnotationMetadataOnly = "shadow.clusterpedia.io/metadata-only"
+
+	// ShadowAnnotationRemovedAt marks the tombstone with the time when the resource is deleted
+	ShadowAnnotationRemovedAt = "shadow.clusterpedia.io/removed-at"
 )
 
 type OrderBy struct {
@@ -39,6 +50,9 @@ type ListOptions struct {
 	Namespaces   []string
 	OrderBy      []OrderBy
 
+	// WithDeleted includes the tombstones of the deleted resources
+	WithDeleted bool
+
 	// +k8s:conversion-fn:drop
 	ExtraLabelSelector labels.Selector
 
@@ -65,6 +79,36 @@ type CollectionResourceList struct {
 	Items []CollectionResource
 }
 
+const (
+	RevisionActionCreated = "Created"
+	RevisionActionUpdated = "Updated"
+	RevisionActionDeleted = "Deleted"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ResourceRevision struct {
+	metav1.TypeMeta
+	metav1.ObjectMeta
+
+	Cluster  string
+	Group    string
+	Version  string
+	Resource string
+	Kind     string
+
+	Action   string
+	SyncedAt metav1.Time
+	Object   runtime.Object
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ResourceRevisionList struct {
+	metav1.TypeMeta
+	metav1.ListMeta
+
+	Items []ResourceRevision
+}
+
 type CollectionResourceType struct {
 	Group    string
 	Version  string
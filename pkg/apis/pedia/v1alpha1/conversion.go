@@ -38,6 +38,7 @@ func Convert_v1alpha1_ListOptions_To_pedia_ListOptions(in *ListOptions, out *ped
 	if err := convert_Slice_string_To_pedia_Slice_orderby(&orderbys, &out.OrderBy, " ", s); err != nil {
 		return err
 	}
+	out.WithDeleted = in.WithDeleted
 
 	if out.LabelSelector != nil {
 		var (
@@ -71,6 +72,14 @@ func Convert_v1alpha1_ListOptions_To_pedia_ListOptions(in *ListOptions, out *ped
 							return err
 						}
 					}
+				case pedia.SearchLabelWithDeleted:
+					if !out.WithDeleted && len(values) != 0 {
+						withDeleted, err := strconv.ParseBool(values[0])
+						if err != nil {
+							return fmt.Errorf("Invalid Query WithDeleted: %w", err)
+						}
+						out.WithDeleted = withDeleted
+					}
 				case pedia.SearchLabelSize:
 					if out.Limit == 0 && len(values) != 0 {
 						size, err := strconv.ParseInt(values[0], 10, 64)
@@ -136,6 +145,7 @@ func Convert_pedia_ListOptions_To_v1alpha1_ListOptions(in *pedia.ListOptions, ou
 	if err := convert_pedia_Slice_orderby_To_String(&in.OrderBy, &out.OrderBy, s); err != nil {
 		return err
 	}
+	out.WithDeleted = in.WithDeleted
 	return nil
 }
 
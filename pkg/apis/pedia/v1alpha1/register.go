@@ -25,6 +25,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&CollectionResource{},
 		&CollectionResourceList{},
+		&ResourceRevision{},
+		&ResourceRevisionList{},
 		&Resources{},
 		&ListOptions{},
 
@@ -32,6 +32,9 @@ type ListOptions struct {
 
 	// +optional
 	OrderBy string `json:"orderby,omitempty"`
+
+	// +optional
+	WithDeleted bool `json:"withDeleted,omitempty"`
 }
 
 // +genclient
@@ -72,3 +75,46 @@ type CollectionResourceList struct {
 
 	Items []CollectionResource `json:"items"`
 }
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ResourceRevision is a recorded version of a resource,
+// the name, namespace and resourceVersion of the metadata are the resource's.
+type ResourceRevision struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Cluster string `json:"cluster"`
+
+	Group string `json:"group"`
+
+	Version string `json:"version"`
+
+	Resource string `json:"resource"`
+
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// Action is the write of the resource that records the revision,
+	// one of Created, Updated and Deleted.
+	Action string `json:"action"`
+
+	// SyncedAt is the time when the revision is written to the storage
+	SyncedAt metav1.Time `json:"syncedAt"`
+
+	// +optional
+	Object runtime.RawExtension `json:"object,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type ResourceRevisionList struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ResourceRevision `json:"items"`
+}
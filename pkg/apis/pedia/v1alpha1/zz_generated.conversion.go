@@ -50,6 +50,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ResourceRevision)(nil), (*pedia.ResourceRevision)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ResourceRevision_To_pedia_ResourceRevision(a.(*ResourceRevision), b.(*pedia.ResourceRevision), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*pedia.ResourceRevision)(nil), (*ResourceRevision)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_pedia_ResourceRevision_To_v1alpha1_ResourceRevision(a.(*pedia.ResourceRevision), b.(*ResourceRevision), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ResourceRevisionList)(nil), (*pedia.ResourceRevisionList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ResourceRevisionList_To_pedia_ResourceRevisionList(a.(*ResourceRevisionList), b.(*pedia.ResourceRevisionList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*pedia.ResourceRevisionList)(nil), (*ResourceRevisionList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_pedia_ResourceRevisionList_To_v1alpha1_ResourceRevisionList(a.(*pedia.ResourceRevisionList), b.(*ResourceRevisionList), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*url.Values)(nil), (*ListOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_url_Values_To_v1alpha1_ListOptions(a.(*url.Values), b.(*ListOptions), scope)
 	}); err != nil {
@@ -193,6 +213,7 @@ func autoConvert_v1alpha1_ListOptions_To_pedia_ListOptions(in *ListOptions, out
 	// WARNING: in.ClusterNames requires manual conversion: inconvertible types (string vs []string)
 	// WARNING: in.Namespaces requires manual conversion: inconvertible types (string vs []string)
 	// WARNING: in.OrderBy requires manual conversion: inconvertible types (string vs []github.com/clusterpedia-io/clusterpedia/pkg/apis/pedia.OrderBy)
+	out.WithDeleted = in.WithDeleted
 	return nil
 }
 
@@ -210,6 +231,7 @@ func autoConvert_pedia_ListOptions_To_v1alpha1_ListOptions(in *pedia.ListOptions
 		return err
 	}
 	// WARNING: in.OrderBy requires manual conversion: inconvertible types ([]github.com/clusterpedia-io/clusterpedia/pkg/apis/pedia.OrderBy vs string)
+	out.WithDeleted = in.WithDeleted
 	// WARNING: in.ExtraLabelSelector requires manual conversion: does not exist in peer-type
 	// WARNING: in.ExtraQuery requires manual conversion: does not exist in peer-type
 	return nil
@@ -253,5 +275,94 @@ func autoConvert_url_Values_To_v1alpha1_ListOptions(in *url.Values, out *ListOpt
 	} else {
 		out.OrderBy = ""
 	}
+	if values, ok := map[string][]string(*in)["withDeleted"]; ok && len(values) > 0 {
+		if err := runtime.Convert_Slice_string_To_bool(&values, &out.WithDeleted, s); err != nil {
+			return err
+		}
+	} else {
+		out.WithDeleted = false
+	}
+	return nil
+}
+
+func autoConvert_v1alpha1_ResourceRevision_To_pedia_ResourceRevision(in *ResourceRevision, out *pedia.ResourceRevision, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	out.Cluster = in.Cluster
+	out.Group = in.Group
+	out.Version = in.Version
+	out.Resource = in.Resource
+	out.Kind = in.Kind
+	out.Action = in.Action
+	out.SyncedAt = in.SyncedAt
+	if err := runtime.Convert_runtime_RawExtension_To_runtime_Object(&in.Object, &out.Object, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_ResourceRevision_To_pedia_ResourceRevision is an autogenerated conversion function.
+func Convert_v1alpha1_ResourceRevision_To_pedia_ResourceRevision(in *ResourceRevision, out *pedia.ResourceRevision, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ResourceRevision_To_pedia_ResourceRevision(in, out, s)
+}
+
+func autoConvert_pedia_ResourceRevision_To_v1alpha1_ResourceRevision(in *pedia.ResourceRevision, out *ResourceRevision, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	out.Cluster = in.Cluster
+	out.Group = in.Group
+	out.Version = in.Version
+	out.Resource = in.Resource
+	out.Kind = in.Kind
+	out.Action = in.Action
+	out.SyncedAt = in.SyncedAt
+	if err := runtime.Convert_runtime_Object_To_runtime_RawExtension(&in.Object, &out.Object, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_pedia_ResourceRevision_To_v1alpha1_ResourceRevision is an autogenerated conversion function.
+func Convert_pedia_ResourceRevision_To_v1alpha1_ResourceRevision(in *pedia.ResourceRevision, out *ResourceRevision, s conversion.Scope) error {
+	return autoConvert_pedia_ResourceRevision_To_v1alpha1_ResourceRevision(in, out, s)
+}
+
+func autoConvert_v1alpha1_ResourceRevisionList_To_pedia_ResourceRevisionList(in *ResourceRevisionList, out *pedia.ResourceRevisionList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]pedia.ResourceRevision, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha1_ResourceRevision_To_pedia_ResourceRevision(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
 	return nil
 }
+
+// Convert_v1alpha1_ResourceRevisionList_To_pedia_ResourceRevisionList is an autogenerated conversion function.
+func Convert_v1alpha1_ResourceRevisionList_To_pedia_ResourceRevisionList(in *ResourceRevisionList, out *pedia.ResourceRevisionList, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ResourceRevisionList_To_pedia_ResourceRevisionList(in, out, s)
+}
+
+func autoConvert_pedia_ResourceRevisionList_To_v1alpha1_ResourceRevisionList(in *pedia.ResourceRevisionList, out *ResourceRevisionList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ResourceRevision, len(*in))
+		for i := range *in {
+			if err := Convert_pedia_ResourceRevision_To_v1alpha1_ResourceRevision(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_pedia_ResourceRevisionList_To_v1alpha1_ResourceRevisionList is an autogenerated conversion function.
+func Convert_pedia_ResourceRevisionList_To_v1alpha1_ResourceRevisionList(in *pedia.ResourceRevisionList, out *ResourceRevisionList, s conversion.Scope) error {
+	return autoConvert_pedia_ResourceRevisionList_To_v1alpha1_ResourceRevisionList(in, out, s)
+}
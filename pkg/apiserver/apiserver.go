@@ -17,10 +17,12 @@ import (
 	"k8s.io/client-go/discovery"
 	clientrest "k8s.io/client-go/rest"
 	"k8s.io/client-go/restmapper"
+	"k8s.io/klog/v2"
 
 	"github.com/clusterpedia-io/clusterpedia/pkg/apis/pedia"
 	pediainstall "github.com/clusterpedia-io/clusterpedia/pkg/apis/pedia/install"
 	pediacollectionresources "github.com/clusterpedia-io/clusterpedia/pkg/apiserver/registry/pedia/collectionresources"
+	pediaresourcerevisions "github.com/clusterpedia-io/clusterpedia/pkg/apiserver/registry/pedia/resourcerevisions"
 	pediaresources "github.com/clusterpedia-io/clusterpedia/pkg/apiserver/registry/pedia/resources"
 	"github.com/clusterpedia-io/clusterpedia/pkg/generated/clientset/versioned"
 	informers "github.com/clusterpedia-io/clusterpedia/pkg/generated/informers/externalversions"
@@ -151,6 +153,14 @@ func (config completedConfig) New() (*ClusterPediaServer, error) {
 	pediav1alpha1storage := map[string]rest.Storage{}
 	pediav1alpha1storage["resources"] = pediaresources.NewREST(kubeResourceAPIServer.Handler)
 	pediav1alpha1storage["collectionresources"] = pediacollectionresources.NewREST(config.StorageFactory)
+	if factory, ok := config.StorageFactory.(storage.ResourceRevisionStorageFactory); ok {
+		revisionStorage, err := factory.NewResourceRevisionStorage()
+		if err != nil {
+			klog.InfoS("Skip resource revisions api", "reason", err.Error())
+		} else {
+			pediav1alpha1storage["resourcerevisions"] = pediaresourcerevisions.NewREST(revisionStorage)
+		}
+	}
 	pediaAPIGroupInfo.VersionedResourcesStorageMap["v1alpha1"] = pediav1alpha1storage
 
 	if err := genericServer.InstallAPIGroup(&pediaAPIGroupInfo); err != nil {
@@ -0,0 +1,149 @@
+package resourcerevisions
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metainternal "k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/registry/rest"
+	storeerr "k8s.io/apiserver/pkg/storage/errors"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/apis/pedia"
+	pediascheme "github.com/clusterpedia-io/clusterpedia/pkg/apis/pedia/scheme"
+	pediav1alpha1 "github.com/clusterpedia-io/clusterpedia/pkg/apis/pedia/v1alpha1"
+	"github.com/clusterpedia-io/clusterpedia/pkg/kubeapiserver/legacyresource"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+	"github.com/clusterpedia-io/clusterpedia/pkg/utils/request"
+)
+
+// the query parameters of the resource revisions,
+// the other parameters are the same as the resources.
+const (
+	queryGroup    = "group"
+	queryVersion  = "version"
+	queryResource = "resource"
+
+	// queryAsOf lists the resources which existed at the time, the time format is RFC3339
+	queryAsOf = "asOf"
+)
+
+// REST implements RESTStorage for ResourceRevisions API,
+// it lists the revisions of the resources, or the resources as of a time.
+type REST struct {
+	storage       storage.ResourceRevisionStorage
+	configFactory *legacyresource.StorageConfigFactory
+}
+
+var _ rest.Lister = &REST{}
+var _ rest.Scoper = &REST{}
+
+func NewREST(storage storage.ResourceRevisionStorage) *REST {
+	return &REST{
+		storage:       storage,
+		configFactory: legacyresource.NewStorageConfigFactory(runtime.ContentTypeJSON),
+	}
+}
+
+func (r *REST) New() runtime.Object {
+	return &pedia.ResourceRevision{}
+}
+
+func (r *REST) NewList() runtime.Object {
+	return &pedia.ResourceRevisionList{}
+}
+
+func (r *REST) NamespaceScoped() bool {
+	return false
+}
+
+func (r *REST) List(ctx context.Context, _ *metainternal.ListOptions) (runtime.Object, error) {
+	var opts pedia.ListOptions
+	query := request.RequestQueryFrom(ctx)
+	if err := pediascheme.ParameterCodec.DecodeParameters(query, pediav1alpha1.SchemeGroupVersion, &opts); err != nil {
+		return nil, apierrors.NewBadRequest(err.Error())
+	}
+
+	gvr := schema.GroupVersionResource{
+		Group:    query.Get(queryGroup),
+		Version:  query.Get(queryVersion),
+		Resource: query.Get(queryResource),
+	}
+	if gvr.Resource == "" {
+		return nil, apierrors.NewBadRequest("resource is required")
+	}
+	gvr, err := r.storageResource(gvr)
+	if err != nil {
+		return nil, apierrors.NewBadRequest(err.Error())
+	}
+
+	var list *pedia.ResourceRevisionList
+	if asOf := query.Get(queryAsOf); asOf != "" {
+		t, perr := time.Parse(time.RFC3339, asOf)
+		if perr != nil {
+			return nil, apierrors.NewBadRequest(fmt.Sprintf("Invalid Query AsOf(%s): %v", asOf, perr))
+		}
+		list, err = r.storage.ListRevisionsAsOf(ctx, gvr, t, &opts)
+	} else {
+		list, err = r.storage.ListRevisions(ctx, gvr, &opts)
+	}
+	if err != nil {
+		return nil, storeerr.InterpretListError(err, gvr.GroupResource())
+	}
+	return list, nil
+}
+
+// storageResource returns the resource with the storage version,
+// the kube resources are stored with the storage version of the clusterpedia,
+// and the custom resources are stored with the synchronized version.
+func (r *REST) storageResource(gvr schema.GroupVersionResource) (schema.GroupVersionResource, error) {
+	if legacyresource.Scheme.IsGroupRegistered(gvr.Group) {
+		config, err := r.configFactory.NewConfig(gvr)
+		if err == nil {
+			return config.StorageGroupResource.WithVersion(config.StorageVersion.Version), nil
+		}
+	}
+
+	if gvr.Version == "" {
+		return schema.GroupVersionResource{}, fmt.Errorf("version is required for custom resource %s", gvr.GroupResource())
+	}
+	return gvr, nil
+}
+
+func (r *REST) ConvertToTable(ctx context.Context, object runtime.Object, tableOptions runtime.Object) (*metav1.Table, error) {
+	table := &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "Cluster", Type: "string"},
+			{Name: "Namespace", Type: "string"},
+			{Name: "Name", Type: "string", Format: "name"},
+			{Name: "Kind", Type: "string"},
+			{Name: "Resource Version", Type: "string"},
+			{Name: "Action", Type: "string"},
+			{Name: "Synced At", Type: "string", Format: "date-time"},
+		},
+	}
+
+	var revisions []pedia.ResourceRevision
+	switch obj := object.(type) {
+	case *pedia.ResourceRevisionList:
+		revisions = obj.Items
+	case *pedia.ResourceRevision:
+		revisions = []pedia.ResourceRevision{*obj}
+	}
+
+	for i := range revisions {
+		revision := &revisions[i]
+		table.Rows = append(table.Rows, metav1.TableRow{
+			Object: runtime.RawExtension{Object: revision.DeepCopy()},
+			Cells: []interface{}{
+				revision.Cluster, revision.Namespace, revision.Name, revision.Kind,
+				revision.ResourceVersion, revision.Action, revision.SyncedAt.UTC().Format(time.RFC3339),
+			},
+		})
+	}
+	return table, nil
+}
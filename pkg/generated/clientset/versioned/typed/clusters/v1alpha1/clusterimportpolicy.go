@@ -0,0 +1,168 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	v1alpha1 "github.com/clusterpedia-io/clusterpedia/pkg/apis/clusters/v1alpha1"
+	scheme "github.com/clusterpedia-io/clusterpedia/pkg/generated/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// ClusterImportPoliciesGetter has a method to return a ClusterImportPolicyInterface.
+// A group's client should implement this interface.
+type ClusterImportPoliciesGetter interface {
+	ClusterImportPolicies() ClusterImportPolicyInterface
+}
+
+// ClusterImportPolicyInterface has methods to work with ClusterImportPolicy resources.
+type ClusterImportPolicyInterface interface {
+	Create(ctx context.Context, clusterImportPolicy *v1alpha1.ClusterImportPolicy, opts v1.CreateOptions) (*v1alpha1.ClusterImportPolicy, error)
+	Update(ctx context.Context, clusterImportPolicy *v1alpha1.ClusterImportPolicy, opts v1.UpdateOptions) (*v1alpha1.ClusterImportPolicy, error)
+	UpdateStatus(ctx context.Context, clusterImportPolicy *v1alpha1.ClusterImportPolicy, opts v1.UpdateOptions) (*v1alpha1.ClusterImportPolicy, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.ClusterImportPolicy, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.ClusterImportPolicyList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.ClusterImportPolicy, err error)
+	ClusterImportPolicyExpansion
+}
+
+// clusterImportPolicies implements ClusterImportPolicyInterface
+type clusterImportPolicies struct {
+	client rest.Interface
+}
+
+// newClusterImportPolicies returns a ClusterImportPolicies
+func newClusterImportPolicies(c *ClustersV1alpha1Client) *clusterImportPolicies {
+	return &clusterImportPolicies{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the clusterImportPolicy, and returns the corresponding clusterImportPolicy object, and an error if there is any.
+func (c *clusterImportPolicies) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.ClusterImportPolicy, err error) {
+	result = &v1alpha1.ClusterImportPolicy{}
+	err = c.client.Get().
+		Resource("clusterimportpolicies").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of ClusterImportPolicies that match those selectors.
+func (c *clusterImportPolicies) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.ClusterImportPolicyList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.ClusterImportPolicyList{}
+	err = c.client.Get().
+		Resource("clusterimportpolicies").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested clusterImportPolicies.
+func (c *clusterImportPolicies) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Resource("clusterimportpolicies").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a clusterImportPolicy and creates it.  Returns the server's representation of the clusterImportPolicy, and an error, if there is any.
+func (c *clusterImportPolicies) Create(ctx context.Context, clusterImportPolicy *v1alpha1.ClusterImportPolicy, opts v1.CreateOptions) (result *v1alpha1.ClusterImportPolicy, err error) {
+	result = &v1alpha1.ClusterImportPolicy{}
+	err = c.client.Post().
+		Resource("clusterimportpolicies").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(clusterImportPolicy).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a clusterImportPolicy and updates it. Returns the server's representation of the clusterImportPolicy, and an error, if there is any.
+func (c *clusterImportPolicies) Update(ctx context.Context, clusterImportPolicy *v1alpha1.ClusterImportPolicy, opts v1.UpdateOptions) (result *v1alpha1.ClusterImportPolicy, err error) {
+	result = &v1alpha1.ClusterImportPolicy{}
+	err = c.client.Put().
+		Resource("clusterimportpolicies").
+		Name(clusterImportPolicy.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(clusterImportPolicy).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *clusterImportPolicies) UpdateStatus(ctx context.Context, clusterImportPolicy *v1alpha1.ClusterImportPolicy, opts v1.UpdateOptions) (result *v1alpha1.ClusterImportPolicy, err error) {
+	result = &v1alpha1.ClusterImportPolicy{}
+	err = c.client.Put().
+		Resource("clusterimportpolicies").
+		Name(clusterImportPolicy.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(clusterImportPolicy).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the clusterImportPolicy and deletes it. Returns an error if one occurs.
+func (c *clusterImportPolicies) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("clusterimportpolicies").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *clusterImportPolicies) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Resource("clusterimportpolicies").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched clusterImportPolicy.
+func (c *clusterImportPolicies) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.ClusterImportPolicy, err error) {
+	result = &v1alpha1.ClusterImportPolicy{}
+	err = c.client.Patch(pt).
+		Resource("clusterimportpolicies").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -10,6 +10,8 @@ import (
 
 type ClustersV1alpha1Interface interface {
 	RESTClient() rest.Interface
+	ClusterImportPoliciesGetter
+	ClusterSyncResourcesesGetter
 	PediaClustersGetter
 }
 
@@ -18,6 +20,14 @@ type ClustersV1alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *ClustersV1alpha1Client) ClusterImportPolicies() ClusterImportPolicyInterface {
+	return newClusterImportPolicies(c)
+}
+
+func (c *ClustersV1alpha1Client) ClusterSyncResourceses() ClusterSyncResourcesInterface {
+	return newClusterSyncResourceses(c)
+}
+
 func (c *ClustersV1alpha1Client) PediaClusters() PediaClusterInterface {
 	return newPediaClusters(c)
 }
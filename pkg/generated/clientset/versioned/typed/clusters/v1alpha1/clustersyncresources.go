@@ -0,0 +1,152 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	v1alpha1 "github.com/clusterpedia-io/clusterpedia/pkg/apis/clusters/v1alpha1"
+	scheme "github.com/clusterpedia-io/clusterpedia/pkg/generated/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// ClusterSyncResourcesesGetter has a method to return a ClusterSyncResourcesInterface.
+// A group's client should implement this interface.
+type ClusterSyncResourcesesGetter interface {
+	ClusterSyncResourceses() ClusterSyncResourcesInterface
+}
+
+// ClusterSyncResourcesInterface has methods to work with ClusterSyncResources resources.
+type ClusterSyncResourcesInterface interface {
+	Create(ctx context.Context, clusterSyncResources *v1alpha1.ClusterSyncResources, opts v1.CreateOptions) (*v1alpha1.ClusterSyncResources, error)
+	Update(ctx context.Context, clusterSyncResources *v1alpha1.ClusterSyncResources, opts v1.UpdateOptions) (*v1alpha1.ClusterSyncResources, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.ClusterSyncResources, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.ClusterSyncResourcesList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.ClusterSyncResources, err error)
+	ClusterSyncResourcesExpansion
+}
+
+// clusterSyncResourceses implements ClusterSyncResourcesInterface
+type clusterSyncResourceses struct {
+	client rest.Interface
+}
+
+// newClusterSyncResourceses returns a ClusterSyncResourceses
+func newClusterSyncResourceses(c *ClustersV1alpha1Client) *clusterSyncResourceses {
+	return &clusterSyncResourceses{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the clusterSyncResources, and returns the corresponding clusterSyncResources object, and an error if there is any.
+func (c *clusterSyncResourceses) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.ClusterSyncResources, err error) {
+	result = &v1alpha1.ClusterSyncResources{}
+	err = c.client.Get().
+		Resource("clustersyncresources").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of ClusterSyncResourceses that match those selectors.
+func (c *clusterSyncResourceses) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.ClusterSyncResourcesList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.ClusterSyncResourcesList{}
+	err = c.client.Get().
+		Resource("clustersyncresources").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested clusterSyncResourceses.
+func (c *clusterSyncResourceses) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Resource("clustersyncresources").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a clusterSyncResources and creates it.  Returns the server's representation of the clusterSyncResources, and an error, if there is any.
+func (c *clusterSyncResourceses) Create(ctx context.Context, clusterSyncResources *v1alpha1.ClusterSyncResources, opts v1.CreateOptions) (result *v1alpha1.ClusterSyncResources, err error) {
+	result = &v1alpha1.ClusterSyncResources{}
+	err = c.client.Post().
+		Resource("clustersyncresources").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(clusterSyncResources).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a clusterSyncResources and updates it. Returns the server's representation of the clusterSyncResources, and an error, if there is any.
+func (c *clusterSyncResourceses) Update(ctx context.Context, clusterSyncResources *v1alpha1.ClusterSyncResources, opts v1.UpdateOptions) (result *v1alpha1.ClusterSyncResources, err error) {
+	result = &v1alpha1.ClusterSyncResources{}
+	err = c.client.Put().
+		Resource("clustersyncresources").
+		Name(clusterSyncResources.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(clusterSyncResources).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the clusterSyncResources and deletes it. Returns an error if one occurs.
+func (c *clusterSyncResourceses) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("clustersyncresources").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *clusterSyncResourceses) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Resource("clustersyncresources").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched clusterSyncResources.
+func (c *clusterSyncResourceses) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.ClusterSyncResources, err error) {
+	result = &v1alpha1.ClusterSyncResources{}
+	err = c.client.Patch(pt).
+		Resource("clustersyncresources").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
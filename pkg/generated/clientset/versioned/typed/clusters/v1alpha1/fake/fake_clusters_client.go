@@ -12,6 +12,14 @@ type FakeClustersV1alpha1 struct {
 	*testing.Fake
 }
 
+func (c *FakeClustersV1alpha1) ClusterImportPolicies() v1alpha1.ClusterImportPolicyInterface {
+	return &FakeClusterImportPolicies{c}
+}
+
+func (c *FakeClustersV1alpha1) ClusterSyncResourceses() v1alpha1.ClusterSyncResourcesInterface {
+	return &FakeClusterSyncResourceses{c}
+}
+
 func (c *FakeClustersV1alpha1) PediaClusters() v1alpha1.PediaClusterInterface {
 	return &FakePediaClusters{c}
 }
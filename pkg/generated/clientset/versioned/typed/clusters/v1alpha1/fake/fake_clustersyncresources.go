@@ -0,0 +1,106 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/clusterpedia-io/clusterpedia/pkg/apis/clusters/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeClusterSyncResourceses implements ClusterSyncResourcesInterface
+type FakeClusterSyncResourceses struct {
+	Fake *FakeClustersV1alpha1
+}
+
+var clustersyncresourcesesResource = schema.GroupVersionResource{Group: "clusters.clusterpedia.io", Version: "v1alpha1", Resource: "clustersyncresources"}
+
+var clustersyncresourcesesKind = schema.GroupVersionKind{Group: "clusters.clusterpedia.io", Version: "v1alpha1", Kind: "ClusterSyncResources"}
+
+// Get takes name of the clusterSyncResources, and returns the corresponding clusterSyncResources object, and an error if there is any.
+func (c *FakeClusterSyncResourceses) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.ClusterSyncResources, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(clustersyncresourcesesResource, name), &v1alpha1.ClusterSyncResources{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ClusterSyncResources), err
+}
+
+// List takes label and field selectors, and returns the list of ClusterSyncResourceses that match those selectors.
+func (c *FakeClusterSyncResourceses) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.ClusterSyncResourcesList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(clustersyncresourcesesResource, clustersyncresourcesesKind, opts), &v1alpha1.ClusterSyncResourcesList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.ClusterSyncResourcesList{ListMeta: obj.(*v1alpha1.ClusterSyncResourcesList).ListMeta}
+	for _, item := range obj.(*v1alpha1.ClusterSyncResourcesList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested clusterSyncResourceses.
+func (c *FakeClusterSyncResourceses) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(clustersyncresourcesesResource, opts))
+}
+
+// Create takes the representation of a clusterSyncResources and creates it.  Returns the server's representation of the clusterSyncResources, and an error, if there is any.
+func (c *FakeClusterSyncResourceses) Create(ctx context.Context, clusterSyncResources *v1alpha1.ClusterSyncResources, opts v1.CreateOptions) (result *v1alpha1.ClusterSyncResources, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(clustersyncresourcesesResource, clusterSyncResources), &v1alpha1.ClusterSyncResources{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ClusterSyncResources), err
+}
+
+// Update takes the representation of a clusterSyncResources and updates it. Returns the server's representation of the clusterSyncResources, and an error, if there is any.
+func (c *FakeClusterSyncResourceses) Update(ctx context.Context, clusterSyncResources *v1alpha1.ClusterSyncResources, opts v1.UpdateOptions) (result *v1alpha1.ClusterSyncResources, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(clustersyncresourcesesResource, clusterSyncResources), &v1alpha1.ClusterSyncResources{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ClusterSyncResources), err
+}
+
+// Delete takes name of the clusterSyncResources and deletes it. Returns an error if one occurs.
+func (c *FakeClusterSyncResourceses) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteAction(clustersyncresourcesesResource, name), &v1alpha1.ClusterSyncResources{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeClusterSyncResourceses) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(clustersyncresourcesesResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.ClusterSyncResourcesList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched clusterSyncResources.
+func (c *FakeClusterSyncResourceses) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.ClusterSyncResources, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(clustersyncresourcesesResource, name, pt, data, subresources...), &v1alpha1.ClusterSyncResources{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ClusterSyncResources), err
+}
@@ -2,4 +2,8 @@
 
 package v1alpha1
 
+type ClusterImportPolicyExpansion interface{}
+
+type ClusterSyncResourcesExpansion interface{}
+
 type PediaClusterExpansion interface{}
@@ -0,0 +1,73 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	time "time"
+
+	clustersv1alpha1 "github.com/clusterpedia-io/clusterpedia/pkg/apis/clusters/v1alpha1"
+	versioned "github.com/clusterpedia-io/clusterpedia/pkg/generated/clientset/versioned"
+	internalinterfaces "github.com/clusterpedia-io/clusterpedia/pkg/generated/informers/externalversions/internalinterfaces"
+	v1alpha1 "github.com/clusterpedia-io/clusterpedia/pkg/generated/listers/clusters/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// ClusterSyncResourcesInformer provides access to a shared informer and lister for
+// ClusterSyncResourceses.
+type ClusterSyncResourcesInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1alpha1.ClusterSyncResourcesLister
+}
+
+type clusterSyncResourcesInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+}
+
+// NewClusterSyncResourcesInformer constructs a new informer for ClusterSyncResources type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewClusterSyncResourcesInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredClusterSyncResourcesInformer(client, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredClusterSyncResourcesInformer constructs a new informer for ClusterSyncResources type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredClusterSyncResourcesInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.ClustersV1alpha1().ClusterSyncResourceses().List(context.TODO(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.ClustersV1alpha1().ClusterSyncResourceses().Watch(context.TODO(), options)
+			},
+		},
+		&clustersv1alpha1.ClusterSyncResources{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *clusterSyncResourcesInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredClusterSyncResourcesInformer(client, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *clusterSyncResourcesInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&clustersv1alpha1.ClusterSyncResources{}, f.defaultInformer)
+}
+
+func (f *clusterSyncResourcesInformer) Lister() v1alpha1.ClusterSyncResourcesLister {
+	return v1alpha1.NewClusterSyncResourcesLister(f.Informer().GetIndexer())
+}
@@ -8,6 +8,10 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// ClusterImportPolicies returns a ClusterImportPolicyInformer.
+	ClusterImportPolicies() ClusterImportPolicyInformer
+	// ClusterSyncResourceses returns a ClusterSyncResourcesInformer.
+	ClusterSyncResourceses() ClusterSyncResourcesInformer
 	// PediaClusters returns a PediaClusterInformer.
 	PediaClusters() PediaClusterInformer
 }
@@ -23,6 +27,16 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// ClusterImportPolicies returns a ClusterImportPolicyInformer.
+func (v *version) ClusterImportPolicies() ClusterImportPolicyInformer {
+	return &clusterImportPolicyInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// ClusterSyncResourceses returns a ClusterSyncResourcesInformer.
+func (v *version) ClusterSyncResourceses() ClusterSyncResourcesInformer {
+	return &clusterSyncResourcesInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // PediaClusters returns a PediaClusterInformer.
 func (v *version) PediaClusters() PediaClusterInformer {
 	return &pediaClusterInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
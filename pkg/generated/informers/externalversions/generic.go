@@ -37,6 +37,10 @@ func (f *genericInformer) Lister() cache.GenericLister {
 func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
 	switch resource {
 	// Group=clusters.clusterpedia.io, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithResource("clusterimportpolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Clusters().V1alpha1().ClusterImportPolicies().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("clustersyncresources"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Clusters().V1alpha1().ClusterSyncResourceses().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("pediaclusters"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Clusters().V1alpha1().PediaClusters().Informer()}, nil
 
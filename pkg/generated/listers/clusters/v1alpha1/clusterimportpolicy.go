@@ -0,0 +1,52 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/clusterpedia-io/clusterpedia/pkg/apis/clusters/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ClusterImportPolicyLister helps list ClusterImportPolicies.
+// All objects returned here must be treated as read-only.
+type ClusterImportPolicyLister interface {
+	// List lists all ClusterImportPolicies in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.ClusterImportPolicy, err error)
+	// Get retrieves the ClusterImportPolicy from the index for a given name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.ClusterImportPolicy, error)
+	ClusterImportPolicyListerExpansion
+}
+
+// clusterImportPolicyLister implements the ClusterImportPolicyLister interface.
+type clusterImportPolicyLister struct {
+	indexer cache.Indexer
+}
+
+// NewClusterImportPolicyLister returns a new ClusterImportPolicyLister.
+func NewClusterImportPolicyLister(indexer cache.Indexer) ClusterImportPolicyLister {
+	return &clusterImportPolicyLister{indexer: indexer}
+}
+
+// List lists all ClusterImportPolicies in the indexer.
+func (s *clusterImportPolicyLister) List(selector labels.Selector) (ret []*v1alpha1.ClusterImportPolicy, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.ClusterImportPolicy))
+	})
+	return ret, err
+}
+
+// Get retrieves the ClusterImportPolicy from the index for a given name.
+func (s *clusterImportPolicyLister) Get(name string) (*v1alpha1.ClusterImportPolicy, error) {
+	obj, exists, err := s.indexer.GetByKey(name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("clusterimportpolicy"), name)
+	}
+	return obj.(*v1alpha1.ClusterImportPolicy), nil
+}
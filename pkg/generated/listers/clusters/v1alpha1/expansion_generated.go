@@ -2,6 +2,14 @@
 
 package v1alpha1
 
+// ClusterImportPolicyListerExpansion allows custom methods to be added to
+// ClusterImportPolicyLister.
+type ClusterImportPolicyListerExpansion interface{}
+
+// ClusterSyncResourcesListerExpansion allows custom methods to be added to
+// ClusterSyncResourcesLister.
+type ClusterSyncResourcesListerExpansion interface{}
+
 // PediaClusterListerExpansion allows custom methods to be added to
 // PediaClusterLister.
 type PediaClusterListerExpansion interface{}
@@ -0,0 +1,27 @@
+package customresource
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/registry/rest"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/kubeapiserver/printers"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+// NewStorageConfig returns the storage config of the custom resource,
+// custom resource is stored as unstructured json with the synchronized version.
+func NewStorageConfig(gvr schema.GroupVersionResource) *storage.ResourceStorageConfig {
+	return &storage.ResourceStorageConfig{
+		GroupResource:        gvr.GroupResource(),
+		StorageGroupResource: gvr.GroupResource(),
+
+		Codec:          unstructured.UnstructuredJSONScheme,
+		StorageVersion: gvr.GroupVersion(),
+		MemoryVersion:  gvr.GroupVersion(),
+	}
+}
+
+func GetTableConvertor(gr schema.GroupResource) rest.TableConvertor {
+	return printers.NewDefaultTableConvertor(gr)
+}
@@ -0,0 +1,130 @@
+package customresource
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer/json"
+	"k8s.io/apimachinery/pkg/runtime/serializer/versioning"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/kubeapiserver/legacyresource"
+)
+
+var (
+	// Scheme handles the unstructured custom resources,
+	// other types(metav1.Status, metav1.Table ...) are delegated to the legacyresource.Scheme
+	Scheme = &unstructuredScheme{delegate: legacyresource.Scheme}
+
+	Codecs runtime.NegotiatedSerializer = unstructuredNegotiatedSerializer{scheme: Scheme}
+)
+
+var (
+	_ runtime.ObjectCreater   = &unstructuredScheme{}
+	_ runtime.ObjectTyper     = &unstructuredScheme{}
+	_ runtime.ObjectConvertor = &unstructuredScheme{}
+	_ runtime.ObjectDefaulter = &unstructuredScheme{}
+)
+
+type unstructuredScheme struct {
+	delegate *runtime.Scheme
+}
+
+func (s *unstructuredScheme) New(kind schema.GroupVersionKind) (runtime.Object, error) {
+	if s.delegate.Recognizes(kind) {
+		return s.delegate.New(kind)
+	}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(kind)
+	return obj, nil
+}
+
+func (s *unstructuredScheme) ObjectKinds(obj runtime.Object) ([]schema.GroupVersionKind, bool, error) {
+	if _, ok := obj.(runtime.Unstructured); !ok {
+		return s.delegate.ObjectKinds(obj)
+	}
+
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	if gvk.Kind == "" {
+		return nil, false, runtime.NewMissingKindErr("object has no kind field ")
+	}
+	if gvk.Version == "" {
+		return nil, false, runtime.NewMissingVersionErr("object has no apiVersion field")
+	}
+	return []schema.GroupVersionKind{gvk}, false, nil
+}
+
+func (s *unstructuredScheme) Recognizes(gvk schema.GroupVersionKind) bool {
+	return true
+}
+
+func (s *unstructuredScheme) Default(obj runtime.Object) {
+	if _, ok := obj.(runtime.Unstructured); !ok {
+		s.delegate.Default(obj)
+	}
+}
+
+func (s *unstructuredScheme) Convert(in, out, context interface{}) error {
+	return s.delegate.Convert(in, out, context)
+}
+
+// ConvertToVersion does not convert custom resources,
+// the custom resource is stored and served with the version it was synchronized.
+func (s *unstructuredScheme) ConvertToVersion(in runtime.Object, target runtime.GroupVersioner) (runtime.Object, error) {
+	if _, ok := in.(runtime.Unstructured); !ok {
+		return s.delegate.ConvertToVersion(in, target)
+	}
+	return in, nil
+}
+
+// ConvertFieldLabel passes through all field labels,
+// the field selector of the custom resource is handled by the storage layer.
+func (s *unstructuredScheme) ConvertFieldLabel(gvk schema.GroupVersionKind, label, value string) (string, string, error) {
+	return label, value, nil
+}
+
+type unstructuredNegotiatedSerializer struct {
+	scheme *unstructuredScheme
+}
+
+func (s unstructuredNegotiatedSerializer) SupportedMediaTypes() []runtime.SerializerInfo {
+	return []runtime.SerializerInfo{
+		{
+			MediaType:        runtime.ContentTypeJSON,
+			MediaTypeType:    "application",
+			MediaTypeSubType: "json",
+			EncodesAsText:    true,
+			Serializer:       json.NewSerializer(json.DefaultMetaFactory, s.scheme, s.scheme, false),
+			PrettySerializer: json.NewSerializer(json.DefaultMetaFactory, s.scheme, s.scheme, true),
+			StreamSerializer: &runtime.StreamSerializerInfo{
+				EncodesAsText: true,
+				Serializer:    json.NewSerializer(json.DefaultMetaFactory, s.scheme, s.scheme, false),
+				Framer:        json.Framer,
+			},
+		},
+		{
+			MediaType:        runtime.ContentTypeYAML,
+			MediaTypeType:    "application",
+			MediaTypeSubType: "yaml",
+			EncodesAsText:    true,
+			Serializer:       json.NewYAMLSerializer(json.DefaultMetaFactory, s.scheme, s.scheme),
+		},
+	}
+}
+
+func (s unstructuredNegotiatedSerializer) EncoderForVersion(encoder runtime.Encoder, gv runtime.GroupVersioner) runtime.Encoder {
+	return versioning.NewCodec(encoder, nil, s.scheme, s.scheme, s.scheme, s.scheme, gv, nil, "unstructuredNegotiatedSerializer")
+}
+
+func (s unstructuredNegotiatedSerializer) DecoderToVersion(decoder runtime.Decoder, gv runtime.GroupVersioner) runtime.Decoder {
+	return versioning.NewCodec(nil, decoder, s.scheme, s.scheme, s.scheme, s.scheme, nil, gv, "unstructuredNegotiatedSerializer")
+}
+
+// NewParameterCodec returns a parameter codec for the custom resource's group version,
+// it is used to decode the options(GetOptions, ListOptions ...) of the request.
+func NewParameterCodec(gv schema.GroupVersion) runtime.ParameterCodec {
+	scheme := runtime.NewScheme()
+	metav1.AddToGroupVersion(scheme, gv)
+	return runtime.NewParameterCodec(scheme)
+}
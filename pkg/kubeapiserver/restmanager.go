@@ -8,12 +8,14 @@ import (
 
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apiserver/pkg/endpoints/handlers"
 	"k8s.io/client-go/restmapper"
 	"k8s.io/klog/v2"
 
+	"github.com/clusterpedia-io/clusterpedia/pkg/kubeapiserver/customresource"
 	"github.com/clusterpedia-io/clusterpedia/pkg/kubeapiserver/discovery"
 	"github.com/clusterpedia-io/clusterpedia/pkg/kubeapiserver/legacyresource"
 	"github.com/clusterpedia-io/clusterpedia/pkg/kubeapiserver/resourcerest"
@@ -118,14 +120,20 @@ func (m *RESTManager) LoadResources(infos ResourceInfoMap) map[schema.GroupResou
 		gk := schema.GroupKind{Group: gr.Group, Kind: info.Kind}
 		versions := legacyresource.Scheme.VersionsForGroupKind(gk)
 		if len(versions) == 0 {
-			// not support custom resource
-			// versions = []schema.GroupVersion{schema.GroupVersion{info.Group, info.Version}}
-			continue
+			// custom resource, only serve the synchronized versions
+			for _, version := range info.Versions.List() {
+				versions = append(versions, schema.GroupVersion{Group: gr.Group, Version: version})
+			}
 		}
 
 		resource, hasResource := apiresources[gr]
 		if !hasResource {
-			resource = metav1.APIResource{Name: gr.Resource, Namespaced: info.Namespaced, Kind: info.Kind}
+			resource = metav1.APIResource{
+				Name:       gr.Resource,
+				Namespaced: info.Namespaced,
+				Kind:       info.Kind,
+				Verbs:      metav1.Verbs{"get", "list"},
+			}
 			addedAPIResources[gr] = resource
 		}
 
@@ -206,8 +214,19 @@ func (m *RESTManager) addRESTResourceInfosLocked(addedInfos map[schema.GroupVers
 			continue
 		}
 
+		gk := schema.GroupKind{Group: gvr.Group, Kind: info.APIResource.Kind}
+		isCustomResource := len(legacyresource.Scheme.VersionsForGroupKind(gk)) == 0
+
 		if info.Storage == nil {
-			storage, err := m.genLegacyResourceRESTStorage(gvr, info.APIResource.Kind)
+			var (
+				storage *resourcerest.RESTStorage
+				err     error
+			)
+			if isCustomResource {
+				storage, err = m.genCustomResourceRESTStorage(gvr, info.APIResource.Kind)
+			} else {
+				storage, err = m.genLegacyResourceRESTStorage(gvr, info.APIResource.Kind)
+			}
 			if err != nil {
 				klog.ErrorS(err, "Failed to gen resource rest storage", "gvr", gvr, "kind", info.APIResource.Kind)
 				continue
@@ -217,7 +236,12 @@ func (m *RESTManager) addRESTResourceInfosLocked(addedInfos map[schema.GroupVers
 		}
 
 		if info.RequestScope == nil {
-			requestScope := m.genLegacyResourceRequestScope(gvr, info.APIResource.Kind, info.APIResource.Namespaced)
+			var requestScope *handlers.RequestScope
+			if isCustomResource {
+				requestScope = m.genCustomResourceRequestScope(gvr, info.APIResource.Kind, info.APIResource.Namespaced)
+			} else {
+				requestScope = m.genLegacyResourceRequestScope(gvr, info.APIResource.Kind, info.APIResource.Namespaced)
+			}
 			requestScope.TableConvertor = info.Storage
 
 			info.RequestScope = requestScope
@@ -257,20 +281,34 @@ func (m *RESTManager) genLegacyResourceRESTStorage(gvr schema.GroupVersionResour
 	}, nil
 }
 
-// TODO: support custom resource
-func (m *RESTManager) genLegacyResourceRequestScope(gvr schema.GroupVersionResource, kind string, namespaced bool) *handlers.RequestScope {
-	namer := handlers.ContextBasedNaming{
-		SelfLinker:    runtime.SelfLinker(meta.NewAccessor()),
-		ClusterScoped: !namespaced,
-	}
-	if gvr.Group == "" {
-		namer.SelfLinkPathPrefix = path.Join("api", gvr.Version) + "/"
-	} else {
-		namer.SelfLinkPathPrefix = path.Join("apis", gvr.Group, gvr.Version) + "/"
+func (m *RESTManager) genCustomResourceRESTStorage(gvr schema.GroupVersionResource, kind string) (*resourcerest.RESTStorage, error) {
+	resourceStorage, err := m.storageFactory.NewResourceStorage(customresource.NewStorageConfig(gvr))
+	if err != nil {
+		return nil, err
 	}
 
+	return &resourcerest.RESTStorage{
+		DefaultQualifiedResource: gvr.GroupResource(),
+
+		NewFunc: func() runtime.Object {
+			obj := &unstructured.Unstructured{}
+			obj.SetGroupVersionKind(gvr.GroupVersion().WithKind(kind))
+			return obj
+		},
+		NewListFunc: func() runtime.Object {
+			obj := &unstructured.UnstructuredList{}
+			obj.SetGroupVersionKind(gvr.GroupVersion().WithKind(kind + "List"))
+			return obj
+		},
+
+		Storage:        resourceStorage,
+		TableConvertor: customresource.GetTableConvertor(gvr.GroupResource()),
+	}, nil
+}
+
+func (m *RESTManager) genLegacyResourceRequestScope(gvr schema.GroupVersionResource, kind string, namespaced bool) *handlers.RequestScope {
 	return &handlers.RequestScope{
-		Namer:          namer,
+		Namer:          newResourceNamer(gvr, namespaced),
 		Serializer:     legacyresource.Codecs,
 		ParameterCodec: legacyresource.ParameterCodec,
 		Creater:        legacyresource.Scheme,
@@ -286,6 +324,37 @@ func (m *RESTManager) genLegacyResourceRequestScope(gvr schema.GroupVersionResou
 	}
 }
 
+func (m *RESTManager) genCustomResourceRequestScope(gvr schema.GroupVersionResource, kind string, namespaced bool) *handlers.RequestScope {
+	return &handlers.RequestScope{
+		Namer:          newResourceNamer(gvr, namespaced),
+		Serializer:     customresource.Codecs,
+		ParameterCodec: customresource.NewParameterCodec(gvr.GroupVersion()),
+		Creater:        customresource.Scheme,
+		Convertor:      customresource.Scheme,
+		Defaulter:      customresource.Scheme,
+		Typer:          customresource.Scheme,
+
+		Resource:         gvr,
+		Kind:             gvr.GroupVersion().WithKind(kind),
+		MetaGroupVersion: metav1.SchemeGroupVersion,
+
+		EquivalentResourceMapper: m.equivalentResourceRegistry,
+	}
+}
+
+func newResourceNamer(gvr schema.GroupVersionResource, namespaced bool) handlers.ContextBasedNaming {
+	namer := handlers.ContextBasedNaming{
+		SelfLinker:    runtime.SelfLinker(meta.NewAccessor()),
+		ClusterScoped: !namespaced,
+	}
+	if gvr.Group == "" {
+		namer.SelfLinkPathPrefix = path.Join("api", gvr.Version) + "/"
+	} else {
+		namer.SelfLinkPathPrefix = path.Join("apis", gvr.Group, gvr.Version) + "/"
+	}
+	return namer
+}
+
 type RESTResourceInfo struct {
 	APIResource  metav1.APIResource
 	RequestScope *handlers.RequestScope
@@ -39,6 +39,7 @@ func (s *CollectionResourceStorage) Get(ctx context.Context, opts *pediainternal
 
 		types[rt.GroupResource()] = &cr.ResourceTypes[i]
 	}
+	query = applyRetentionToQuery(query, opts)
 	query = applyListOptionsToQuery(query, opts)
 
 	var resources []Resource
@@ -55,6 +56,7 @@ func (s *CollectionResourceStorage) Get(ctx context.Context, opts *pediainternal
 		if err := caseSensitiveJSONIterator.Unmarshal(resource.Object, obj); err != nil {
 			return nil, InterpreError(s.collectionResource.Name, err)
 		}
+		markRemoved(obj, resource)
 
 		objs = append(objs, obj)
 	}
@@ -36,6 +36,30 @@ type Config struct {
 	Params map[string]string `yaml:"params"`
 
 	Log *LogConfig `yaml:"log"`
+
+	// Retention keeps the deleted resources as tombstones, it is disabled if it is nil
+	Retention *RetentionConfig `yaml:"retention"`
+
+	// History records the revisions of the resources, it is disabled if it is nil
+	History *HistoryConfig `yaml:"history"`
+}
+
+type RetentionConfig struct {
+	// TTL is how long the tombstones are retained after the resources are deleted
+	TTL time.Duration `yaml:"ttl" default:"168h"`
+
+	// GCInterval is the interval of collecting the expired tombstones
+	GCInterval time.Duration `yaml:"gcInterval" default:"10m"`
+}
+
+func (cfg *RetentionConfig) Validate() error {
+	if cfg.TTL <= 0 {
+		return errors.New("retention ttl must be greater than 0")
+	}
+	if cfg.GCInterval <= 0 {
+		return errors.New("retention gcInterval must be greater than 0")
+	}
+	return nil
 }
 
 type LogConfig struct {
@@ -68,6 +92,24 @@ type MySQLConfig struct {
 	RejectReadOnly          *bool `yaml:"rejectReadOnly"`          // Reject read-only connections
 }
 
+type HistoryConfig struct {
+	// Retention is how long the revisions are retained
+	Retention time.Duration `yaml:"retention" default:"720h"`
+
+	// GCInterval is the interval of collecting the expired revisions
+	GCInterval time.Duration `yaml:"gcInterval" default:"1h"`
+}
+
+func (cfg *HistoryConfig) Validate() error {
+	if cfg.Retention <= 0 {
+		return errors.New("history retention must be greater than 0")
+	}
+	if cfg.GCInterval <= 0 {
+		return errors.New("history gcInterval must be greater than 0")
+	}
+	return nil
+}
+
 type PostgresConfig struct{}
 
 func (cfg *Config) LoggerConfig() (logger.Config, error) {
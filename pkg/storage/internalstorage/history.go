@@ -0,0 +1,182 @@
+package internalstorage
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	pediainternal "github.com/clusterpedia-io/clusterpedia/pkg/apis/pedia"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+// ResourceRevision is a version of the resource written by the ResourceStorage
+type ResourceRevision struct {
+	ID uint `gorm:"primaryKey"`
+
+	Group    string `gorm:"size:63;not null;index:idx_group_version_resource_cluster_namespace_name"`
+	Version  string `gorm:"size:15;not null;index:idx_group_version_resource_cluster_namespace_name"`
+	Resource string `gorm:"size:63;not null;index:idx_group_version_resource_cluster_namespace_name"`
+	Kind     string `gorm:"size:63;not null"`
+
+	Cluster         string `gorm:"size:253;not null;index:idx_group_version_resource_cluster_namespace_name,length:100"`
+	Namespace       string `gorm:"size:253;not null;index:idx_group_version_resource_cluster_namespace_name,length:50"`
+	Name            string `gorm:"size:253;not null;index:idx_group_version_resource_cluster_namespace_name,length:100"`
+	UID             string `gorm:"size:36;not null"`
+	ResourceVersion string `gorm:"size:30;not null"`
+	Action          string `gorm:"size:15;not null"`
+	Object          datatypes.JSON
+	SyncedAt        time.Time `gorm:"not null;index"`
+}
+
+func newResourceRevision(action string, resource Resource) ResourceRevision {
+	return ResourceRevision{
+		Group:           resource.Group,
+		Version:         resource.Version,
+		Resource:        resource.Resource,
+		Kind:            resource.Kind,
+		Cluster:         resource.Cluster,
+		Namespace:       resource.Namespace,
+		Name:            resource.Name,
+		UID:             string(resource.UID),
+		ResourceVersion: resource.ResourceVersion,
+		Action:          action,
+		Object:          resource.Object,
+		SyncedAt:        time.Now(),
+	}
+}
+
+// runHistoryGC deletes the revisions whose retention is expired,
+// the latest revision of each resource before the expired time is retained unless it is deleted,
+// so that the resources can still be listed as of the times within the retention.
+func runHistoryGC(db *gorm.DB, config HistoryConfig, stopCh <-chan struct{}) {
+	wait.Until(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), config.GCInterval)
+		defer cancel()
+
+		expired := time.Now().Add(-config.Retention)
+		latest := db.Model(&ResourceRevision{}).Select("MAX(id) AS id").
+			Where("synced_at < ?", expired).
+			Clauses(clause.GroupBy{Columns: resourceUniqueColumns})
+
+		// the derived table is required by MySQL to select from the table that is deleted from
+		retained := db.Table("(?) AS latest", latest).Select("id")
+		result := db.WithContext(ctx).Where("synced_at < ?", expired).
+			Where(db.Where("id NOT IN (?)", retained).Or("action = ?", pediainternal.RevisionActionDeleted)).
+			Delete(&ResourceRevision{})
+		if result.Error != nil {
+			klog.ErrorS(result.Error, "Failed to collect expired resource revisions")
+			return
+		}
+		if result.RowsAffected != 0 {
+			klog.V(2).InfoS("expired resource revisions are collected", "count", result.RowsAffected)
+		}
+	}, config.GCInterval, stopCh)
+}
+
+type ResourceRevisionStorage struct {
+	db *gorm.DB
+}
+
+var _ storage.ResourceRevisionStorage = &ResourceRevisionStorage{}
+
+func (s *StorageFactory) NewResourceRevisionStorage() (storage.ResourceRevisionStorage, error) {
+	if s.history == nil {
+		return nil, errors.New("the history of the internal storage is disabled")
+	}
+	return &ResourceRevisionStorage{db: s.db}, nil
+}
+
+func (s *ResourceRevisionStorage) ListRevisions(ctx context.Context, gvr schema.GroupVersionResource, opts *pediainternal.ListOptions) (*pediainternal.ResourceRevisionList, error) {
+	query := s.db.WithContext(ctx).Where(map[string]interface{}{
+		"group":    gvr.Group,
+		"version":  gvr.Version,
+		"resource": gvr.Resource,
+	})
+	query = applyRevisionFiltersToQuery(query, opts)
+	query = applyRevisionPageToQuery(query.Order("id DESC"), opts)
+	return s.list(query, gvr)
+}
+
+func (s *ResourceRevisionStorage) ListRevisionsAsOf(ctx context.Context, gvr schema.GroupVersionResource, asOf time.Time, opts *pediainternal.ListOptions) (*pediainternal.ResourceRevisionList, error) {
+	// the ids of the revisions are increasing, so the max id is the latest revision of the resource
+	latest := s.db.Model(&ResourceRevision{}).Select("MAX(id)").Where(map[string]interface{}{
+		"group":    gvr.Group,
+		"version":  gvr.Version,
+		"resource": gvr.Resource,
+	}).Where("synced_at <= ?", asOf)
+	latest = applyRevisionFiltersToQuery(latest, opts).Group("cluster, namespace, name")
+
+	query := s.db.WithContext(ctx).Where("id IN (?)", latest).Where("action <> ?", pediainternal.RevisionActionDeleted)
+	query = applyRevisionPageToQuery(query.Order("cluster").Order("namespace").Order("name"), opts)
+	return s.list(query, gvr)
+}
+
+func (s *ResourceRevisionStorage) list(query *gorm.DB, gvr schema.GroupVersionResource) (*pediainternal.ResourceRevisionList, error) {
+	var revisions []ResourceRevision
+	if result := query.Find(&revisions); result.Error != nil {
+		return nil, InterpreError(gvr.String(), result.Error)
+	}
+
+	list := &pediainternal.ResourceRevisionList{
+		Items: make([]pediainternal.ResourceRevision, 0, len(revisions)),
+	}
+	for _, revision := range revisions {
+		item := pediainternal.ResourceRevision{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            revision.Name,
+				Namespace:       revision.Namespace,
+				ResourceVersion: revision.ResourceVersion,
+			},
+			Cluster:  revision.Cluster,
+			Group:    revision.Group,
+			Version:  revision.Version,
+			Resource: revision.Resource,
+			Kind:     revision.Kind,
+			Action:   revision.Action,
+			SyncedAt: metav1.NewTime(revision.SyncedAt),
+		}
+
+		if len(revision.Object) != 0 {
+			obj := &unstructured.Unstructured{}
+			if err := caseSensitiveJSONIterator.Unmarshal(revision.Object, obj); err != nil {
+				return nil, InterpreError(gvr.String(), err)
+			}
+			item.Object = obj
+		}
+		list.Items = append(list.Items, item)
+	}
+	return list, nil
+}
+
+func applyRevisionFiltersToQuery(query *gorm.DB, opts *pediainternal.ListOptions) *gorm.DB {
+	if len(opts.ClusterNames) != 0 {
+		query = query.Where("cluster IN ?", opts.ClusterNames)
+	}
+	if len(opts.Namespaces) != 0 {
+		query = query.Where("namespace IN ?", opts.Namespaces)
+	}
+	if len(opts.Names) != 0 {
+		query = query.Where("name IN ?", opts.Names)
+	}
+	return query
+}
+
+func applyRevisionPageToQuery(query *gorm.DB, opts *pediainternal.ListOptions) *gorm.DB {
+	if opts.Limit > 0 {
+		query = query.Limit(int(opts.Limit))
+	}
+	if offset, err := strconv.Atoi(opts.Continue); err == nil {
+		query = query.Offset(offset)
+	}
+	return query
+}
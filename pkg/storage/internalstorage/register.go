@@ -67,7 +67,21 @@ func NewStorageFactory(configPath string) (storage.StorageFactory, error) {
 		return nil, err
 	}
 
-	return &StorageFactory{db}, nil
+	if cfg.History != nil {
+		if err := cfg.History.Validate(); err != nil {
+			return nil, err
+		}
+		if err := db.AutoMigrate(&ResourceRevision{}); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.Retention != nil {
+		if err := cfg.Retention.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return &StorageFactory{db: db, retention: cfg.Retention, history: cfg.History}, nil
 }
 
 func newLogger(cfg *Config) (logger.Interface, error) {
@@ -6,8 +6,11 @@ import (
 	"database/sql"
 	"fmt"
 	"reflect"
+	"time"
 
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/conversion"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -17,29 +20,185 @@ import (
 	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
 )
 
+var (
+	resourceUniqueColumns = []clause.Column{
+		{Name: "group"}, {Name: "version"}, {Name: "resource"},
+		{Name: "cluster"}, {Name: "namespace"}, {Name: "name"},
+	}
+
+	// the upserted resource replaces the tombstone with the same key
+	resourceUpsertColumns = []string{"kind", "uid", "resource_version", "object", "created_at", "synced_at", "deleted_at", "removed_at"}
+)
+
 type ResourceStorage struct {
 	db    *gorm.DB
 	codec runtime.Codec
 
+	// retention keeps the deleted resources as tombstones
+	retention bool
+
+	// history records the revisions of the resources
+	history bool
+
 	storageGroupResource schema.GroupResource
 	storageVersion       schema.GroupVersion
 	memoryVersion        schema.GroupVersion
 }
 
 func (s *ResourceStorage) Create(ctx context.Context, cluster string, obj runtime.Object) error {
+	resource, err := s.genResource(cluster, obj)
+	if err != nil {
+		return err
+	}
+
+	// the resource may be recreated with the name of a tombstone,
+	// and the tombstone is replaced by the new resource.
+	err = s.write(pediainternal.RevisionActionCreated, []Resource{resource}, s.retention, func(tx *gorm.DB) *gorm.DB {
+		if s.retention {
+			tombstone := Resource{
+				Cluster:   resource.Cluster,
+				Namespace: resource.Namespace,
+				Name:      resource.Name,
+				Group:     resource.Group,
+				Resource:  resource.Resource,
+				Version:   resource.Version,
+			}
+			if result := tx.Where(&tombstone).Where("removed_at IS NOT NULL").Delete(&Resource{}); result.Error != nil {
+				return result
+			}
+		}
+		return tx.Create(&resource)
+	})
+	return InterpreResourceError(cluster, resource.Name, err)
+}
+
+// write runs the write of the resources, the revisions of the resources are recorded
+// in the same transaction if the history is enabled.
+func (s *ResourceStorage) write(action string, resources []Resource, transaction bool, write func(tx *gorm.DB) *gorm.DB) error {
+	if !s.history && !transaction {
+		return write(s.db).Error
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		result := write(tx)
+		if result.Error != nil || !s.history || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		revisions := make([]ResourceRevision, 0, len(resources))
+		for _, resource := range resources {
+			revisions = append(revisions, newResourceRevision(action, resource))
+		}
+		return tx.Create(&revisions).Error
+	})
+}
+
+func (s *ResourceStorage) BatchUpsert(ctx context.Context, cluster string, objs []runtime.Object) error {
+	if len(objs) == 0 {
+		return nil
+	}
+
+	// the rows of a multi-row upsert can not conflict with each other,
+	// the latter object overwrites the former one with the same key.
+	indexes := make(map[string]int, len(objs))
+	resources := make([]Resource, 0, len(objs))
+	for _, obj := range objs {
+		resource, err := s.genResource(cluster, obj)
+		if err != nil {
+			return err
+		}
+
+		key := resource.Namespace + "/" + resource.Name
+		if index, ok := indexes[key]; ok {
+			resources[index] = resource
+			continue
+		}
+		indexes[key] = len(resources)
+		resources = append(resources, resource)
+	}
+
+	upsert := func(tx *gorm.DB) *gorm.DB {
+		return tx.WithContext(ctx).Clauses(clause.OnConflict{
+			Columns:   resourceUniqueColumns,
+			DoUpdates: clause.AssignmentColumns(resourceUpsertColumns),
+		}).Create(&resources)
+	}
+	if !s.history {
+		return InterpreError(s.storageGroupResource.String(), upsert(s.db).Error)
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		// the revision of the resource which exists before the upsert is recorded as updated,
+		// and the one which is new or replaces a tombstone is recorded as created.
+		existing, err := s.existingResources(tx.WithContext(ctx), cluster, resources)
+		if err != nil {
+			return err
+		}
+
+		result := upsert(tx)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		revisions := make([]ResourceRevision, 0, len(resources))
+		for _, resource := range resources {
+			action := pediainternal.RevisionActionCreated
+			if existing[resource.Namespace+"/"+resource.Name] {
+				action = pediainternal.RevisionActionUpdated
+			}
+			revisions = append(revisions, newResourceRevision(action, resource))
+		}
+		return tx.Create(&revisions).Error
+	})
+	return InterpreError(s.storageGroupResource.String(), err)
+}
+
+// existingResources returns the keys of the resources which are stored and not removed
+func (s *ResourceStorage) existingResources(tx *gorm.DB, cluster string, resources []Resource) (map[string]bool, error) {
+	names := make([]string, 0, len(resources))
+	for _, resource := range resources {
+		names = append(names, resource.Name)
+	}
+
+	var keys []struct {
+		Namespace string
+		Name      string
+	}
+	result := tx.Model(&Resource{}).Select("namespace", "name").
+		Where(&Resource{
+			Cluster:  cluster,
+			Group:    s.storageGroupResource.Group,
+			Resource: s.storageGroupResource.Resource,
+			Version:  s.storageVersion.Version,
+		}).
+		Where("name IN ?", names).
+		Where("removed_at IS NULL").
+		Find(&keys)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	existing := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		existing[key.Namespace+"/"+key.Name] = true
+	}
+	return existing, nil
+}
+
+func (s *ResourceStorage) genResource(cluster string, obj runtime.Object) (Resource, error) {
 	gvk := obj.GetObjectKind().GroupVersionKind()
 	if gvk.Kind == "" {
-		return fmt.Errorf("%s: kind is required", gvk)
+		return Resource{}, fmt.Errorf("%s: kind is required", gvk)
 	}
 
 	metaobj, err := meta.Accessor(obj)
 	if err != nil {
-		return InterpreError("", err)
+		return Resource{}, InterpreError("", err)
 	}
 
 	var buffer bytes.Buffer
 	if err := s.codec.Encode(obj, &buffer); err != nil {
-		return InterpreResourceError(cluster, metaobj.GetName(), err)
+		return Resource{}, InterpreResourceError(cluster, metaobj.GetName(), err)
 	}
 
 	resource := Resource{
@@ -58,9 +217,7 @@ func (s *ResourceStorage) Create(ctx context.Context, cluster string, obj runtim
 	if deletedAt := metaobj.GetDeletionTimestamp(); deletedAt != nil {
 		resource.DeletedAt = sql.NullTime{Time: deletedAt.Time, Valid: true}
 	}
-
-	result := s.db.Create(&resource)
-	return InterpreResourceError(cluster, metaobj.GetName(), result.Error)
+	return resource, nil
 }
 
 func (s *ResourceStorage) Update(ctx context.Context, cluster string, obj runtime.Object) error {
@@ -74,12 +231,15 @@ func (s *ResourceStorage) Update(ctx context.Context, cluster string, obj runtim
 		return InterpreResourceError(cluster, metaobj.GetName(), err)
 	}
 
-	updatedResource := Resource{
-		ResourceVersion: metaobj.GetResourceVersion(),
-		Object:          buffer.Bytes(),
+	updatedResource := map[string]interface{}{
+		"resource_version": metaobj.GetResourceVersion(),
+		"object":           datatypes.JSON(buffer.Bytes()),
+
+		// the resource is alive again if it is a tombstone
+		"removed_at": nil,
 	}
 	if deletedAt := metaobj.GetDeletionTimestamp(); deletedAt != nil {
-		updatedResource.DeletedAt = sql.NullTime{Time: deletedAt.Time, Valid: true}
+		updatedResource["deleted_at"] = sql.NullTime{Time: deletedAt.Time, Valid: true}
 	}
 
 	resource := Resource{
@@ -91,8 +251,14 @@ func (s *ResourceStorage) Update(ctx context.Context, cluster string, obj runtim
 		Resource:  s.storageGroupResource.Resource,
 		Version:   s.storageVersion.Version,
 	}
-	result := s.db.Where(&resource).Updates(&updatedResource)
-	return InterpreResourceError(cluster, metaobj.GetName(), result.Error)
+	revision := resource
+	revision.Kind = obj.GetObjectKind().GroupVersionKind().Kind
+	revision.ResourceVersion = metaobj.GetResourceVersion()
+	revision.Object = buffer.Bytes()
+	err = s.write(pediainternal.RevisionActionUpdated, []Resource{revision}, false, func(tx *gorm.DB) *gorm.DB {
+		return tx.Model(&Resource{}).Where(&resource).Updates(updatedResource)
+	})
+	return InterpreResourceError(cluster, metaobj.GetName(), err)
 }
 
 func (s *ResourceStorage) Delete(ctx context.Context, cluster string, obj runtime.Object) error {
@@ -110,8 +276,21 @@ func (s *ResourceStorage) Delete(ctx context.Context, cluster string, obj runtim
 		Version:   s.storageVersion.Version,
 	}
 
-	result := s.db.Where(&resource).Delete(&Resource{})
-	return InterpreResourceError(cluster, metaobj.GetName(), result.Error)
+	// the revision of the deleted resource keeps the final state of the object
+	revision, err := s.genResource(cluster, obj)
+	if err != nil {
+		revision = resource
+		revision.UID = metaobj.GetUID()
+		revision.ResourceVersion = metaobj.GetResourceVersion()
+	}
+
+	err = s.write(pediainternal.RevisionActionDeleted, []Resource{revision}, false, func(tx *gorm.DB) *gorm.DB {
+		if s.retention {
+			return tx.Model(&Resource{}).Where(&resource).Where("removed_at IS NULL").Update("removed_at", time.Now())
+		}
+		return tx.Where(&resource).Delete(&Resource{})
+	})
+	return InterpreResourceError(cluster, metaobj.GetName(), err)
 }
 
 func (s *ResourceStorage) Get(ctx context.Context, cluster, namespace, name string, into runtime.Object) error {
@@ -124,7 +303,7 @@ func (s *ResourceStorage) Get(ctx context.Context, cluster, namespace, name stri
 		Version:   s.storageVersion.Version,
 	}
 
-	result := s.db.WithContext(ctx).Select("object").Where(&resource).First(&resource)
+	result := s.db.WithContext(ctx).Select("object").Where(&resource).Where("removed_at IS NULL").First(&resource)
 	if result.Error != nil {
 		return InterpreResourceError(cluster, namespace+"/"+name, result.Error)
 	}
@@ -146,6 +325,7 @@ func (s *ResourceStorage) List(ctx context.Context, listObject runtime.Object, o
 		"version":  s.storageVersion.Version,
 		"resource": s.storageGroupResource.Resource,
 	})
+	query = applyRetentionToQuery(query, opts)
 	query = applyListOptionsToQuery(query, opts)
 
 	var resources []Resource
@@ -166,7 +346,7 @@ func (s *ResourceStorage) List(ctx context.Context, listObject runtime.Object, o
 
 	newItemFunc := getNewItemFunc(listObject, v)
 	for _, resource := range resources {
-		if err := appendListItem(v, resource.Object, s.codec, newItemFunc); err != nil {
+		if err := appendListItem(v, resource, s.codec, newItemFunc); err != nil {
 			return InterpreError(s.storageGroupResource.String(), fmt.Errorf("need ptr to slice: %v", err))
 		}
 	}
@@ -0,0 +1,58 @@
+package internalstorage
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	pediainternal "github.com/clusterpedia-io/clusterpedia/pkg/apis/pedia"
+)
+
+// runRetentionGC deletes the tombstones whose retention is expired
+func runRetentionGC(db *gorm.DB, config RetentionConfig, stopCh <-chan struct{}) {
+	wait.Until(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), config.GCInterval)
+		defer cancel()
+
+		expired := time.Now().Add(-config.TTL)
+		result := db.WithContext(ctx).Where("removed_at IS NOT NULL AND removed_at < ?", expired).Delete(&Resource{})
+		if result.Error != nil {
+			klog.ErrorS(result.Error, "Failed to collect expired tombstones")
+			return
+		}
+		if result.RowsAffected != 0 {
+			klog.V(2).InfoS("expired tombstones are collected", "count", result.RowsAffected)
+		}
+	}, config.GCInterval, stopCh)
+}
+
+func applyRetentionToQuery(query *gorm.DB, opts *pediainternal.ListOptions) *gorm.DB {
+	if opts.WithDeleted {
+		return query
+	}
+	return query.Where("removed_at IS NULL")
+}
+
+// markRemoved sets the removed time of the tombstone to the object's annotations
+func markRemoved(obj runtime.Object, resource Resource) {
+	if !resource.RemovedAt.Valid {
+		return
+	}
+
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return
+	}
+
+	annotations := accessor.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string, 1)
+	}
+	annotations[pediainternal.ShadowAnnotationRemovedAt] = resource.RemovedAt.Time.UTC().Format(time.RFC3339)
+	accessor.SetAnnotations(annotations)
+}
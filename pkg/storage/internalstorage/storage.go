@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"sync"
 	"time"
 
 	"gorm.io/datatypes"
@@ -35,6 +36,10 @@ type Resource struct {
 	CreatedAt time.Time `gorm:"not null"`
 	SyncedAt  time.Time `gorm:"not null;autoUpdateTime"`
 	DeletedAt sql.NullTime
+
+	// RemovedAt is the time when the resource is deleted from the cluster,
+	// the removed resource is retained as a tombstone if the retention is enabled.
+	RemovedAt sql.NullTime `gorm:"index"`
 }
 
 // SelectedResource used to select specific fields
@@ -50,13 +55,39 @@ func (res Resource) GroupVersionResource() schema.GroupVersionResource {
 }
 
 type StorageFactory struct {
-	db *gorm.DB
+	db        *gorm.DB
+	retention *RetentionConfig
+	history   *HistoryConfig
+}
+
+var _ storage.GarbageCollector = &StorageFactory{}
+
+// RunGarbageCollection collects the expired tombstones and revisions until stopCh is closed
+func (s *StorageFactory) RunGarbageCollection(stopCh <-chan struct{}) {
+	var waitGroup sync.WaitGroup
+	if s.retention != nil {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			runRetentionGC(s.db, *s.retention, stopCh)
+		}()
+	}
+	if s.history != nil {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			runHistoryGC(s.db, *s.history, stopCh)
+		}()
+	}
+	waitGroup.Wait()
 }
 
 func (s *StorageFactory) NewResourceStorage(config *storage.ResourceStorageConfig) (storage.ResourceStorage, error) {
 	return &ResourceStorage{
-		db:    s.db,
-		codec: config.Codec,
+		db:        s.db,
+		codec:     config.Codec,
+		retention: s.retention != nil,
+		history:   s.history != nil,
 
 		storageGroupResource: config.StorageGroupResource,
 		storageVersion:       config.StorageVersion,
@@ -80,6 +111,7 @@ func (f *StorageFactory) GetResourceVersions(ctx context.Context, cluster string
 	result := f.db.WithContext(ctx).
 		Select("group", "version", "resource", "namespace", "name", "resource_version").
 		Where(&Resource{Cluster: cluster}).
+		Where("removed_at IS NULL").
 		Find(&resources)
 
 	if result.Error != nil {
@@ -105,8 +137,8 @@ func (f *StorageFactory) GetResourceVersions(ctx context.Context, cluster string
 }
 
 func (f *StorageFactory) CleanCluster(ctx context.Context, cluster string) error {
-	result := f.db.WithContext(ctx).Where(&Resource{Cluster: cluster}).Delete(Resource{})
-	return InterpreError(cluster, result.Error)
+	err := f.cleanResources(ctx, Resource{Cluster: cluster})
+	return InterpreError(cluster, err)
 }
 
 func (s *StorageFactory) CleanClusterResource(ctx context.Context, cluster string, gvr schema.GroupVersionResource) error {
@@ -117,8 +149,33 @@ func (s *StorageFactory) CleanClusterResource(ctx context.Context, cluster strin
 		Version:  gvr.Version,
 	}
 
-	result := s.db.Where(&resource).Delete(&Resource{})
-	return InterpreError(fmt.Sprintf("%s/%s", cluster, gvr), result.Error)
+	err := s.cleanResources(ctx, resource)
+	return InterpreError(fmt.Sprintf("%s/%s", cluster, gvr), err)
+}
+
+// cleanResources deletes the resources matched by the condition, the deleted revisions of
+// the alive resources are recorded in the same transaction if the history is enabled,
+// so that the cleaned resources are not listed by the asOf queries after they are cleaned.
+func (s *StorageFactory) cleanResources(ctx context.Context, condition Resource) error {
+	if s.history == nil {
+		return s.db.WithContext(ctx).Where(&condition).Delete(&Resource{}).Error
+	}
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// the revisions of the tombstones are recorded when they are removed
+		var resources []Resource
+		result := tx.Where(&condition).Where("removed_at IS NULL").FindInBatches(&resources, 500, func(_ *gorm.DB, _ int) error {
+			revisions := make([]ResourceRevision, 0, len(resources))
+			for _, resource := range resources {
+				revisions = append(revisions, newResourceRevision(pediainternal.RevisionActionDeleted, resource))
+			}
+			return tx.Create(&revisions).Error
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		return tx.Where(&condition).Delete(&Resource{}).Error
+	})
 }
 
 func (s *StorageFactory) GetCollectionResources(ctx context.Context) ([]*pediainternal.CollectionResource, error) {
@@ -135,11 +135,12 @@ func getNewItemFunc(listObj runtime.Object, v reflect.Value) func() runtime.Obje
 	}
 }
 
-func appendListItem(v reflect.Value, data []byte, codec runtime.Codec, newItemFunc func() runtime.Object) error {
-	obj, _, err := codec.Decode(data, nil, newItemFunc())
+func appendListItem(v reflect.Value, resource Resource, codec runtime.Codec, newItemFunc func() runtime.Object) error {
+	obj, _, err := codec.Decode(resource.Object, nil, newItemFunc())
 	if err != nil {
 		return err
 	}
+	markRemoved(obj, resource)
 	v.Set(reflect.Append(v, reflect.ValueOf(obj).Elem()))
 	return nil
 }
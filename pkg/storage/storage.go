@@ -2,6 +2,7 @@ package storage
 
 import (
 	"context"
+	"time"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -29,6 +30,30 @@ type ResourceStorage interface {
 	Create(ctx context.Context, cluster string, obj runtime.Object) error
 	Update(ctx context.Context, cluster string, obj runtime.Object) error
 	Delete(ctx context.Context, cluster string, obj runtime.Object) error
+
+	// BatchUpsert creates or updates the resources in a single request
+	BatchUpsert(ctx context.Context, cluster string, objs []runtime.Object) error
+}
+
+// ResourceRevisionStorageFactory is optionally implemented by the StorageFactory
+// which records the revisions of the resources written by the ResourceStorage.
+type ResourceRevisionStorageFactory interface {
+	NewResourceRevisionStorage() (ResourceRevisionStorage, error)
+}
+
+// GarbageCollector is optionally implemented by the StorageFactory which collects the expired data,
+// the garbage collection is run by only one process, eg. the leader of the clustersynchro managers.
+type GarbageCollector interface {
+	// RunGarbageCollection collects the expired data periodically until stopCh is closed
+	RunGarbageCollection(stopCh <-chan struct{})
+}
+
+type ResourceRevisionStorage interface {
+	// ListRevisions lists the revisions of the resources, the latest revision is first
+	ListRevisions(ctx context.Context, gvr schema.GroupVersionResource, opts *pediainternal.ListOptions) (*pediainternal.ResourceRevisionList, error)
+
+	// ListRevisionsAsOf lists the latest revisions of the resources which existed at the time
+	ListRevisionsAsOf(ctx context.Context, gvr schema.GroupVersionResource, asOf time.Time, opts *pediainternal.ListOptions) (*pediainternal.ResourceRevisionList, error)
 }
 
 type CollectionResourceStorage interface {
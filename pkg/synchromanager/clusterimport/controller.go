@@ -0,0 +1,487 @@
+package clusterimport
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	clustersv1alpha1 "github.com/clusterpedia-io/clusterpedia/pkg/apis/clusters/v1alpha1"
+	crdclientset "github.com/clusterpedia-io/clusterpedia/pkg/generated/clientset/versioned"
+	"github.com/clusterpedia-io/clusterpedia/pkg/generated/informers/externalversions"
+	clusterlister "github.com/clusterpedia-io/clusterpedia/pkg/generated/listers/clusters/v1alpha1"
+)
+
+const (
+	ImportPolicyLabel      = "clusterpedia.io/import-policy"
+	ImportSourceAnnotation = "clusterpedia.io/import-source"
+)
+
+// Controller creates and manages the lifecycle of the PediaClusters
+// that are imported from the source objects of the ClusterImportPolicies.
+type Controller struct {
+	dynamicclient      dynamic.Interface
+	clusterpediaclient crdclientset.Interface
+	informerFactory    externalversions.SharedInformerFactory
+
+	queue           workqueue.RateLimitingInterface
+	policylister    clusterlister.ClusterImportPolicyLister
+	policyInformer  cache.SharedIndexInformer
+	clusterlister   clusterlister.PediaClusterLister
+	clusterInformer cache.SharedIndexInformer
+
+	sourcelock sync.Mutex
+	sources    map[string]*sourceInformer
+}
+
+type sourceInformer struct {
+	source   clustersv1alpha1.ImportSource
+	informer cache.SharedIndexInformer
+	stopCh   chan struct{}
+
+	// listErr is the last error of listing and watching the source objects
+	listErr atomic.Value // listError
+}
+
+// listError wraps the error, since the values stored in the atomic.Value must be of the same type
+type listError struct {
+	err error
+}
+
+// initialListError returns the last list error if the source objects have not been listed,
+// eg. the source resource is not served or forbidden.
+func (s *sourceInformer) initialListError() error {
+	if s.informer.HasSynced() {
+		return nil
+	}
+	listErr, _ := s.listErr.Load().(listError)
+	return listErr.err
+}
+
+func NewController(dynamicclient dynamic.Interface, client crdclientset.Interface) *Controller {
+	factory := externalversions.NewSharedInformerFactory(client, 0)
+	policyinformer := factory.Clusters().V1alpha1().ClusterImportPolicies()
+	clusterinformer := factory.Clusters().V1alpha1().PediaClusters()
+
+	controller := &Controller{
+		dynamicclient:      dynamicclient,
+		clusterpediaclient: client,
+		informerFactory:    factory,
+
+		policylister:    policyinformer.Lister(),
+		policyInformer:  policyinformer.Informer(),
+		clusterlister:   clusterinformer.Lister(),
+		clusterInformer: clusterinformer.Informer(),
+		queue: workqueue.NewRateLimitingQueue(
+			workqueue.NewItemExponentialFailureRateLimiter(2*time.Second, time.Minute),
+		),
+
+		sources: make(map[string]*sourceInformer),
+	}
+
+	policyinformer.Informer().AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc: controller.enqueue,
+			UpdateFunc: func(older, newer interface{}) {
+				oldObj := older.(*clustersv1alpha1.ClusterImportPolicy)
+				newObj := newer.(*clustersv1alpha1.ClusterImportPolicy)
+				if newObj.DeletionTimestamp.IsZero() && equality.Semantic.DeepEqual(oldObj.Spec, newObj.Spec) {
+					return
+				}
+				controller.enqueue(newer)
+			},
+			DeleteFunc: controller.enqueue,
+		},
+	)
+
+	// the imported clusters are reconciled if they are changed by others
+	clusterinformer.Informer().AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc: controller.enqueuePolicyForCluster,
+			UpdateFunc: func(older, newer interface{}) {
+				oldObj := older.(*clustersv1alpha1.PediaCluster)
+				newObj := newer.(*clustersv1alpha1.PediaCluster)
+				if equality.Semantic.DeepEqual(oldObj.Spec, newObj.Spec) &&
+					equality.Semantic.DeepEqual(oldObj.Labels, newObj.Labels) &&
+					equality.Semantic.DeepEqual(oldObj.Annotations, newObj.Annotations) {
+					return
+				}
+				controller.enqueuePolicyForCluster(newer)
+			},
+			DeleteFunc: controller.enqueuePolicyForCluster,
+		},
+	)
+	return controller
+}
+
+func (c *Controller) Run(workers int, stopCh <-chan struct{}) {
+	klog.Info("Start Cluster Import Controller")
+	c.informerFactory.Start(stopCh)
+	if !cache.WaitForCacheSync(stopCh, c.policyInformer.HasSynced, c.clusterInformer.HasSynced) {
+		return
+	}
+
+	var waitGroup sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		waitGroup.Add(1)
+
+		go func() {
+			defer waitGroup.Done()
+			wait.Until(c.worker, time.Second, stopCh)
+		}()
+	}
+
+	<-stopCh
+	c.queue.ShutDown()
+	waitGroup.Wait()
+
+	c.sourcelock.Lock()
+	for name := range c.sources {
+		c.stopSourceInformerLocked(name)
+	}
+	c.sourcelock.Unlock()
+	klog.Info("cluster import controller stoped.")
+}
+
+func (c *Controller) enqueue(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		return
+	}
+	c.queue.Add(key)
+}
+
+func (c *Controller) enqueuePolicyForCluster(obj interface{}) {
+	if d, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = d.Obj
+	}
+	cluster, ok := obj.(*clustersv1alpha1.PediaCluster)
+	if !ok {
+		return
+	}
+
+	if policy := cluster.Labels[ImportPolicyLabel]; policy != "" {
+		c.queue.Add(policy)
+	}
+}
+
+func (c *Controller) worker() {
+	for c.processNextPolicy() {
+	}
+}
+
+func (c *Controller) processNextPolicy() bool {
+	key, shutdown := c.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	name := key.(string)
+	if err := c.reconcilePolicy(name); err != nil {
+		klog.ErrorS(err, "Failed to reconcile cluster import policy", "policy", name, "num requeues", c.queue.NumRequeues(key))
+		c.queue.AddRateLimited(key)
+		return true
+	}
+	c.queue.Forget(key)
+	return true
+}
+
+func (c *Controller) reconcilePolicy(name string) error {
+	policy, err := c.policylister.Get(name)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+
+		// the imported clusters are deleted by the garbage collector
+		klog.InfoS("cluster import policy has been deleted", "policy", name)
+		c.stopSourceInformer(name)
+		return nil
+	}
+	if !policy.DeletionTimestamp.IsZero() {
+		c.stopSourceInformer(name)
+		return nil
+	}
+
+	source, err := c.ensureSourceInformer(policy)
+	if err != nil {
+		return c.updatePolicyStatus(policy, 0, "InvalidSource", err)
+	}
+	informer := source.informer
+	if !informer.HasSynced() {
+		// the imported clusters are kept until the source objects are listed
+		if err := source.initialListError(); err != nil {
+			return c.updatePolicyStatus(policy, policy.Status.ImportedClusters, "InvalidSource", fmt.Errorf("failed to list source: %w", err))
+		}
+		c.queue.AddAfter(name, time.Second)
+		return nil
+	}
+
+	desired := make(map[string]*clustersv1alpha1.PediaCluster)
+	// the clusters whose source failed to be rendered are kept as they are
+	failed := make(map[string]struct{})
+	var errs []error
+	for _, obj := range informer.GetStore().List() {
+		source, ok := obj.(*unstructured.Unstructured)
+		if !ok {
+			continue
+		}
+
+		cluster, err := c.desiredCluster(policy, source)
+		if err != nil {
+			failed[clusterName(policy, source)] = struct{}{}
+			errs = append(errs, fmt.Errorf("%s: %w", sourceKey(source), err))
+			continue
+		}
+		if cluster != nil {
+			desired[cluster.Name] = cluster
+		}
+	}
+
+	imported, err := c.clusterlister.List(labels.SelectorFromSet(labels.Set{ImportPolicyLabel: policy.Name}))
+	if err != nil {
+		return err
+	}
+	for _, cluster := range imported {
+		if _, ok := desired[cluster.Name]; ok {
+			continue
+		}
+		if _, ok := failed[cluster.Name]; ok {
+			continue
+		}
+		if !cluster.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		klog.InfoS("delete imported cluster", "policy", policy.Name, "cluster", cluster.Name)
+		err := c.clusterpediaclient.ClustersV1alpha1().PediaClusters().Delete(context.TODO(), cluster.Name, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, err)
+		}
+	}
+
+	var importedCount int
+	for _, cluster := range desired {
+		if err := c.createOrUpdateCluster(policy, cluster); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		importedCount++
+	}
+
+	reason := "Imported"
+	if len(errs) != 0 {
+		reason = "ImportFailed"
+	}
+	return c.updatePolicyStatus(policy, importedCount, reason, utilerrors.NewAggregate(errs))
+}
+
+// desiredCluster returns nil if the cluster should not be imported from the source object
+func (c *Controller) desiredCluster(policy *clustersv1alpha1.ClusterImportPolicy, source *unstructured.Unstructured) (*clustersv1alpha1.PediaCluster, error) {
+	name := clusterName(policy, source)
+	data := map[string]interface{}{"source": source.Object}
+
+	if policy.Spec.DeletionCondition != "" {
+		deleted, err := renderCondition("deletionCondition", policy.Spec.DeletionCondition, data)
+		if err != nil {
+			return nil, err
+		}
+		if deleted {
+			return nil, nil
+		}
+	}
+
+	// the creation condition only gates the creation of the cluster
+	if _, err := c.clusterlister.Get(name); apierrors.IsNotFound(err) && policy.Spec.CreationCondition != "" {
+		created, err := renderCondition("creationCondition", policy.Spec.CreationCondition, data)
+		if err != nil {
+			return nil, err
+		}
+		if !created {
+			return nil, nil
+		}
+	}
+
+	spec, err := renderClusterSpec(policy, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &clustersv1alpha1.PediaCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Labels:      map[string]string{ImportPolicyLabel: policy.Name},
+			Annotations: map[string]string{ImportSourceAnnotation: sourceKey(source)},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(policy, clustersv1alpha1.SchemeGroupVersion.WithKind("ClusterImportPolicy")),
+			},
+		},
+		Spec: *spec,
+	}, nil
+}
+
+func (c *Controller) createOrUpdateCluster(policy *clustersv1alpha1.ClusterImportPolicy, cluster *clustersv1alpha1.PediaCluster) error {
+	existing, err := c.clusterlister.Get(cluster.Name)
+	if apierrors.IsNotFound(err) {
+		klog.InfoS("create imported cluster", "policy", policy.Name, "cluster", cluster.Name)
+		_, err := c.clusterpediaclient.ClustersV1alpha1().PediaClusters().Create(context.TODO(), cluster, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	if existing.Labels[ImportPolicyLabel] != policy.Name {
+		return fmt.Errorf("cluster %s already exists and is not imported by the policy", cluster.Name)
+	}
+
+	if equality.Semantic.DeepEqual(existing.Spec, cluster.Spec) &&
+		existing.Annotations[ImportSourceAnnotation] == cluster.Annotations[ImportSourceAnnotation] {
+		return nil
+	}
+
+	existing = existing.DeepCopy()
+	existing.Spec = cluster.Spec
+	if existing.Annotations == nil {
+		existing.Annotations = make(map[string]string)
+	}
+	existing.Annotations[ImportSourceAnnotation] = cluster.Annotations[ImportSourceAnnotation]
+
+	klog.InfoS("update imported cluster", "policy", policy.Name, "cluster", cluster.Name)
+	_, err = c.clusterpediaclient.ClustersV1alpha1().PediaClusters().Update(context.TODO(), existing, metav1.UpdateOptions{})
+	return err
+}
+
+// updatePolicyStatus updates the status of the policy, and returns the import error
+func (c *Controller) updatePolicyStatus(policy *clustersv1alpha1.ClusterImportPolicy, imported int, reason string, importErr error) error {
+	condition := metav1.Condition{
+		Type:               clustersv1alpha1.ClusterImportPolicyConditionReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             reason,
+		ObservedGeneration: policy.Generation,
+	}
+	if importErr != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Message = importErr.Error()
+	}
+
+	status := policy.Status.DeepCopy()
+	status.ImportedClusters = imported
+	meta.SetStatusCondition(&status.Conditions, condition)
+	if equality.Semantic.DeepEqual(&policy.Status, status) {
+		return importErr
+	}
+
+	policy = policy.DeepCopy()
+	policy.Status = *status
+	if _, err := c.clusterpediaclient.ClustersV1alpha1().ClusterImportPolicies().UpdateStatus(context.TODO(), policy, metav1.UpdateOptions{}); err != nil {
+		klog.ErrorS(err, "Failed to update cluster import policy status", "policy", policy.Name)
+		if importErr == nil {
+			return err
+		}
+	}
+	return importErr
+}
+
+func (c *Controller) ensureSourceInformer(policy *clustersv1alpha1.ClusterImportPolicy) (*sourceInformer, error) {
+	c.sourcelock.Lock()
+	defer c.sourcelock.Unlock()
+
+	if source, ok := c.sources[policy.Name]; ok {
+		if equality.Semantic.DeepEqual(source.source, policy.Spec.Source) {
+			return source, nil
+		}
+
+		klog.InfoS("import source is changed, restart source informer", "policy", policy.Name)
+		c.stopSourceInformerLocked(policy.Name)
+	}
+
+	source := policy.Spec.Source
+	var labelSelector string
+	if source.LabelSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(source.LabelSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid label selector: %w", err)
+		}
+		labelSelector = selector.String()
+	}
+
+	gvr := schema.GroupVersionResource{Group: source.Group, Version: source.Version, Resource: source.Resource}
+	informer := dynamicinformer.NewFilteredDynamicInformer(c.dynamicclient, gvr, source.Namespace, 0, cache.Indexers{},
+		func(options *metav1.ListOptions) {
+			options.LabelSelector = labelSelector
+		},
+	).Informer()
+
+	name := policy.Name
+	enqueue := func(interface{}) { c.queue.Add(name) }
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    enqueue,
+		UpdateFunc: func(_, newer interface{}) { enqueue(newer) },
+		DeleteFunc: enqueue,
+	})
+
+	si := &sourceInformer{
+		source:   *source.DeepCopy(),
+		informer: informer,
+		stopCh:   make(chan struct{}),
+	}
+	_ = informer.SetWatchErrorHandler(func(r *cache.Reflector, err error) {
+		cache.DefaultWatchErrorHandler(r, err)
+
+		// the policy is reconciled to report the list error if the source objects have not been listed
+		si.listErr.Store(listError{err: err})
+		if !informer.HasSynced() {
+			c.queue.Add(name)
+		}
+	})
+	go informer.Run(si.stopCh)
+
+	c.sources[policy.Name] = si
+	return si, nil
+}
+
+func (c *Controller) stopSourceInformer(name string) {
+	c.sourcelock.Lock()
+	defer c.sourcelock.Unlock()
+
+	c.stopSourceInformerLocked(name)
+}
+
+func (c *Controller) stopSourceInformerLocked(name string) {
+	if source, ok := c.sources[name]; ok {
+		close(source.stopCh)
+		delete(c.sources, name)
+	}
+}
+
+func clusterName(policy *clustersv1alpha1.ClusterImportPolicy, source *unstructured.Unstructured) string {
+	if namespace := source.GetNamespace(); namespace != "" {
+		return policy.Spec.NamePrefix + namespace + "-" + source.GetName()
+	}
+	return policy.Spec.NamePrefix + source.GetName()
+}
+
+func sourceKey(source *unstructured.Unstructured) string {
+	if namespace := source.GetNamespace(); namespace != "" {
+		return namespace + "/" + source.GetName()
+	}
+	return source.GetName()
+}
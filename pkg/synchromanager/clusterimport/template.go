@@ -0,0 +1,72 @@
+package clusterimport
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+
+	"sigs.k8s.io/yaml"
+
+	clustersv1alpha1 "github.com/clusterpedia-io/clusterpedia/pkg/apis/clusters/v1alpha1"
+)
+
+var funcs = template.FuncMap{
+	"b64enc": func(s string) string {
+		return base64.StdEncoding.EncodeToString([]byte(s))
+	},
+	"b64dec": func(s string) (string, error) {
+		data, err := base64.StdEncoding.DecodeString(s)
+		return string(data), err
+	},
+	"default": func(def interface{}, value interface{}) interface{} {
+		if value == nil {
+			return def
+		}
+		if v := reflect.ValueOf(value); v.IsZero() {
+			return def
+		}
+		return value
+	},
+}
+
+func render(name, text string, data interface{}) (string, error) {
+	tmpl, err := template.New(name).Funcs(funcs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+
+	var buffer bytes.Buffer
+	if err := tmpl.Execute(&buffer, data); err != nil {
+		return "", fmt.Errorf("failed to render %s: %w", name, err)
+	}
+	return buffer.String(), nil
+}
+
+// renderCondition returns true if the condition template is rendered to `true`
+func renderCondition(name, condition string, data interface{}) (bool, error) {
+	result, err := render(name, condition, data)
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(result) == "true", nil
+}
+
+func renderClusterSpec(policy *clustersv1alpha1.ClusterImportPolicy, data interface{}) (*clustersv1alpha1.ClusterSpec, error) {
+	result, err := render("template", policy.Spec.Template, data)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := &clustersv1alpha1.ClusterSpec{}
+	if err := yaml.UnmarshalStrict([]byte(result), spec); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the rendered template: %w", err)
+	}
+
+	if len(spec.Resources) == 0 {
+		spec.Resources = policy.Spec.Resources
+	}
+	return spec, nil
+}
@@ -0,0 +1,87 @@
+package clustersynchro
+
+import (
+	"net/http"
+	"sync"
+
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/transport"
+)
+
+// dynamicBearerToken allows the bearer token of the cluster to be rotated
+// without rebuilding the clients and the informers of the cluster synchro.
+type dynamicBearerToken struct {
+	lock  sync.RWMutex
+	token string
+}
+
+func (t *dynamicBearerToken) Get() string {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	return t.token
+}
+
+func (t *dynamicBearerToken) Set(token string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.token = token
+}
+
+func (t *dynamicBearerToken) WrapTransport(rt http.RoundTripper) http.RoundTripper {
+	return &bearerTokenRoundTripper{token: t, rt: rt}
+}
+
+type bearerTokenRoundTripper struct {
+	token *dynamicBearerToken
+	rt    http.RoundTripper
+}
+
+func (rt *bearerTokenRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if len(req.Header.Get("Authorization")) != 0 {
+		return rt.rt.RoundTrip(req)
+	}
+
+	req = utilnet.CloneRequest(req)
+	req.Header.Set("Authorization", "Bearer "+rt.token.Get())
+	return rt.rt.RoundTrip(req)
+}
+
+func (rt *bearerTokenRoundTripper) WrappedRoundTripper() http.RoundTripper { return rt.rt }
+
+// withDynamicBearerToken returns the config used by the clients,
+// the static bearer token of the config is replaced with a dynamic bearer token.
+func withDynamicBearerToken(config *rest.Config) (*rest.Config, *dynamicBearerToken) {
+	if config.BearerToken == "" || config.BearerTokenFile != "" || config.ExecProvider != nil || config.AuthProvider != nil {
+		return config, nil
+	}
+
+	token := &dynamicBearerToken{token: config.BearerToken}
+	config = rest.CopyConfig(config)
+	config.BearerToken = ""
+	config.WrapTransport = transport.Wrappers(config.WrapTransport, token.WrapTransport)
+	return config, token
+}
+
+// RotateBearerToken updates the bearer token of the cluster synchro if only the token of the config is changed,
+// it returns false if the cluster synchro needs to be rebuilt.
+func (s *ClusterSynchro) RotateBearerToken(config *rest.Config) bool {
+	if s.bearerToken == nil || config.BearerToken == "" {
+		return false
+	}
+
+	older, newer := rest.CopyConfig(s.RESTConfig()), rest.CopyConfig(config)
+	older.BearerToken, newer.BearerToken = "", ""
+	if !ConfigEqual(older, newer) {
+		return false
+	}
+
+	s.bearerToken.Set(config.BearerToken)
+	s.restConfig.Store(config)
+	return true
+}
+
+// RESTConfig returns the config of the cluster, it is replaced when the bearer token is rotated
+func (s *ClusterSynchro) RESTConfig() *rest.Config {
+	return s.restConfig.Load().(*rest.Config)
+}
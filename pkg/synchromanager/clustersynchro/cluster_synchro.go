@@ -9,26 +9,34 @@ import (
 	"sync/atomic"
 	"time"
 
-	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/metadata"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
-	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 
 	clustersv1alpha1 "github.com/clusterpedia-io/clusterpedia/pkg/apis/clusters/v1alpha1"
+	"github.com/clusterpedia-io/clusterpedia/pkg/kubeapiserver/customresource"
 	"github.com/clusterpedia-io/clusterpedia/pkg/kubeapiserver/legacyresource"
 	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
 	"github.com/clusterpedia-io/clusterpedia/pkg/synchromanager/clustersynchro/informer"
+	"github.com/clusterpedia-io/clusterpedia/pkg/synchromanager/metrics"
 )
 
+const finalStatusUpdateTimeout = 5 * time.Second
+
 type ClusterStatusUpdater interface {
 	UpdateClusterStatus(ctx context.Context, name string, status *clustersv1alpha1.ClusterStatus) error
 }
@@ -36,12 +44,18 @@ type ClusterStatusUpdater interface {
 type ClusterSynchro struct {
 	name string
 
-	RESTConfig           *rest.Config
+	restConfig           atomic.Value // *rest.Config
 	ClusterStatusUpdater ClusterStatusUpdater
+	options              Options
+	bearerToken          *dynamicBearerToken
+	listLimiter          *listLimiter
 
-	restmapper           meta.RESTMapper
-	clusterclient        kubernetes.Interface
-	listerWatcherFactory informer.DynamicListerWatcherFactory
+	// the discovery and the restmapper are cached, they are refreshed by the discovery refresher
+	discovery                    discovery.CachedDiscoveryInterface
+	restmapper                   *restmapper.DeferredDiscoveryRESTMapper
+	clusterclient                kubernetes.Interface
+	listerWatcherFactory         informer.DynamicListerWatcherFactory
+	metadataListerWatcherFactory informer.DynamicListerWatcherFactory
 
 	storage                     storage.StorageFactory
 	legacyResourceStorageConfig *legacyresource.StorageConfigFactory
@@ -50,67 +64,153 @@ type ClusterSynchro struct {
 	closer    chan struct{}
 	closed    chan struct{}
 
-	status chan struct{}
+	status               chan struct{}
+	statusUpdaterStopped chan struct{}
+	monitorStopped       chan struct{}
 
 	runResourceSynchroCh  chan struct{}
 	stopResourceSynchroCh chan struct{}
 
+	discoveryRefreshCh chan struct{}
+
 	resourcelock  sync.RWMutex
 	handlerStopCh chan struct{}
 	// Key is the storage resource.
 	// Sometimes the synchronized resource and the storage resource are different
 	resourceVersionCaches map[schema.GroupVersionResource]*informer.ResourceVersionStorage
 	resourceSynchros      atomic.Value // map[schema.GroupVersionResource]*ResourceSynchro
+	resourceSelectors     map[schema.GroupVersionResource]resourceSelector
+	syncResources         map[schema.GroupVersionResource]schema.GroupVersionResource
+	listConfig            clustersv1alpha1.ListConfig
+	paused                bool
 
 	resourceStatuses atomic.Value // map[schema.GroupResource]*clustersv1alpha1.ClusterResourceStatus
 
+	// setResourcesLock serializes the SetResources,
+	// the resources set last time are kept to re-expand the wildcard resources.
+	setResourcesLock  sync.Mutex
+	resourcesSet      bool
+	clusterResources  []clustersv1alpha1.ClusterResource
+	pruneConfig       *clustersv1alpha1.PruneConfig
+	expandedResources []clustersv1alpha1.ClusterResource
+
 	version        atomic.Value // version.Info
 	readyCondition atomic.Value // metav1.Condition
+
+	// healthCheckFailures is the number of the consecutive failures of the health checks,
+	// it is only accessed by the monitor.
+	healthCheckFailures int
+
+	// the statistics of the resource synchros are published at the StatisticsPublishInterval or
+	// when the sync statuses are changed, so that the cluster status is not updated for every event.
+	// they are only accessed by the status updater and by GracefulShutdown after the updater is stopped.
+	statisticsPublishedAt time.Time
+	publishedStatistics   map[schema.GroupVersionResource]resourceStatistics
+}
+
+// resourceStatistics are the statistics of a resource synchro published in the cluster status
+type resourceStatistics struct {
+	status            string
+	reason            string
+	initialListSynced bool
+
+	storedResourceCount int
+	processedEventCount int64
+	queueDepth          int
+	lastEventTime       *metav1.Time
+	lastWriteTime       *metav1.Time
+}
+
+func newResourceStatistics(status clustersv1alpha1.ClusterResourceSyncCondition) resourceStatistics {
+	return resourceStatistics{
+		status:              status.Status,
+		reason:              status.Reason,
+		initialListSynced:   status.InitialListSynced,
+		storedResourceCount: status.StoredResourceCount,
+		processedEventCount: status.ProcessedEventCount,
+		queueDepth:          status.QueueDepth,
+		lastEventTime:       status.LastEventTime,
+		lastWriteTime:       status.LastWriteTime,
+	}
 }
 
-func New(name string, config *rest.Config, storage storage.StorageFactory, updater ClusterStatusUpdater) (*ClusterSynchro, error) {
-	clusterclient, err := kubernetes.NewForConfig(config)
+// transited returns true if the sync status of the resource is changed since the statistics are published
+func (stats resourceStatistics) transited(status clustersv1alpha1.ClusterResourceSyncCondition) bool {
+	return stats.status != status.Status || stats.reason != status.Reason || stats.initialListSynced != status.InitialListSynced
+}
+
+func New(name string, config *rest.Config, storage storage.StorageFactory, updater ClusterStatusUpdater, options Options) (*ClusterSynchro, error) {
+	clientConfig, bearerToken := withDynamicBearerToken(config)
+
+	// the rate limits of the manager are used if the rate limits of the cluster are not set
+	clientConfig = rest.CopyConfig(clientConfig)
+	if clientConfig.QPS == 0 {
+		clientConfig.QPS = options.ClientQPS
+	}
+	if clientConfig.Burst == 0 {
+		clientConfig.Burst = options.ClientBurst
+	}
+
+	clusterclient, err := kubernetes.NewForConfig(clientConfig)
 	if err != nil {
-		return nil, err
+		return nil, &CreationError{Reason: CreationReasonInvalidCredentials, Err: err}
 	}
 
-	dynamaicclient, err := dynamic.NewForConfig(config)
+	dynamaicclient, err := dynamic.NewForConfig(clientConfig)
 	if err != nil {
-		return nil, err
+		return nil, &CreationError{Reason: CreationReasonInvalidCredentials, Err: err}
 	}
 
-	mapper, err := apiutil.NewDynamicRESTMapper(config)
+	metadataclient, err := metadata.NewForConfig(clientConfig)
 	if err != nil {
-		return nil, err
+		return nil, &CreationError{Reason: CreationReasonInvalidCredentials, Err: err}
+	}
+
+	// the cluster is probed before creating the cluster synchro, so that the unreachable cluster and
+	// the invalid credentials are reported, and the creation is retried with backoff.
+	if health := checkClusterHealth(clusterclient.Discovery().RESTClient(), clientConfig.Proxy != nil, 0); !health.ready {
+		return nil, &CreationError{Reason: health.reason, Retryable: true, Err: errors.New(health.message)}
 	}
 
+	cachedDiscovery := memory.NewMemCacheClient(clusterclient.Discovery())
 	resourceversions, err := storage.GetResourceVersions(context.TODO(), name)
 	if err != nil {
-		return nil, err
+		return nil, &CreationError{Reason: CreationReasonStorageUnavailable, Retryable: true, Err: err}
 	}
 
 	synchro := &ClusterSynchro{
 		name:                 name,
-		RESTConfig:           config,
 		ClusterStatusUpdater: updater,
 		storage:              storage,
-
-		restmapper:                  mapper,
-		clusterclient:               clusterclient,
-		listerWatcherFactory:        informer.NewDynamicListWatcherFactory(dynamaicclient),
-		legacyResourceStorageConfig: legacyresource.NewStorageConfigFactory(runtime.ContentTypeJSON),
-
-		status: make(chan struct{}),
-		closer: make(chan struct{}),
-		closed: make(chan struct{}),
+		options:              options,
+		bearerToken:          bearerToken,
+		listLimiter:          newListLimiter(options.MaxConcurrentLists),
+
+		discovery:                    cachedDiscovery,
+		restmapper:                   restmapper.NewDeferredDiscoveryRESTMapper(cachedDiscovery),
+		clusterclient:                clusterclient,
+		listerWatcherFactory:         informer.NewDynamicListWatcherFactory(dynamaicclient),
+		metadataListerWatcherFactory: informer.NewMetadataListWatcherFactory(metadataclient),
+		legacyResourceStorageConfig:  legacyresource.NewStorageConfigFactory(runtime.ContentTypeJSON),
+
+		status:               make(chan struct{}),
+		statusUpdaterStopped: make(chan struct{}),
+		monitorStopped:       make(chan struct{}),
+		closer:               make(chan struct{}),
+		closed:               make(chan struct{}),
 
 		runResourceSynchroCh:  make(chan struct{}),
 		stopResourceSynchroCh: make(chan struct{}),
+		discoveryRefreshCh:    make(chan struct{}, 1),
 
 		resourceVersionCaches: make(map[schema.GroupVersionResource]*informer.ResourceVersionStorage),
+		resourceSelectors:     make(map[schema.GroupVersionResource]resourceSelector),
+		syncResources:         make(map[schema.GroupVersionResource]schema.GroupVersionResource),
 	}
 	synchro.resourceSynchros.Store(map[schema.GroupVersionResource]*ResourceSynchro{})
 	synchro.resourceStatuses.Store(map[schema.GroupResource]*clustersv1alpha1.ClusterResourceStatus{})
+	synchro.restConfig.Store(config)
+	synchro.version.Store(version.Info{})
 
 	condition := metav1.Condition{
 		Type:               clustersv1alpha1.ClusterConditionReady,
@@ -125,6 +225,8 @@ func New(name string, config *rest.Config, storage storage.StorageFactory, updat
 	go synchro.Monitor()
 	go synchro.clusterStatusUpdater()
 	go synchro.resourceSynchroRunner()
+	go synchro.discoveryRefresher()
+	go synchro.runCRDWatcher(metadataclient)
 	return synchro, nil
 }
 
@@ -143,18 +245,95 @@ func (s *ClusterSynchro) initWithResourceVersions(resourceversions map[schema.Gr
 	s.resourceVersionCaches = resourceVersionCaches
 }
 
+// SetMaxConcurrentLists sets the max number of the concurrent list requests to the cluster,
+// the default of the manager is used if max is 0.
+func (s *ClusterSynchro) SetMaxConcurrentLists(max int) {
+	if max <= 0 {
+		max = s.options.MaxConcurrentLists
+	}
+	s.listLimiter.SetLimit(max)
+}
+
+// SetListConfig sets the list config of the resource synchros,
+// the resource synchros are rebuilt with the new list config by SetResources.
+func (s *ClusterSynchro) SetListConfig(config *clustersv1alpha1.ListConfig) {
+	s.resourcelock.Lock()
+	defer s.resourcelock.Unlock()
+
+	if config == nil {
+		s.listConfig = clustersv1alpha1.ListConfig{}
+		return
+	}
+	s.listConfig = *config.DeepCopy()
+}
+
+// SetPaused pauses or resumes all resource synchros of the cluster by SetResources
+func (s *ClusterSynchro) SetPaused(paused bool) {
+	s.resourcelock.Lock()
+	defer s.resourcelock.Unlock()
+	s.paused = paused
+}
+
 type syncConfig struct {
+	kind            string
 	syncResource    schema.GroupVersionResource
 	storageResource schema.GroupVersionResource
 	convertor       runtime.ObjectConvertor
 	storageConfig   *storage.ResourceStorageConfig
+	selector        resourceSelector
+	pruner          *resourcePruner
+	paused          bool
+
+	// watchable is false if the resource does not support watch, it is polled instead
+	watchable bool
 }
 
-func (s *ClusterSynchro) SetResources(clusterResources []clustersv1alpha1.ClusterResource) {
+// SetResources sets the resources to be synchronized,
+// pruneConfig is the default prune config of the resources.
+// The wildcard groups and resources are expanded with the discovery of the cluster,
+// and the resources are set again when the discovery is refreshed.
+func (s *ClusterSynchro) SetResources(clusterResources []clustersv1alpha1.ClusterResource, pruneConfig *clustersv1alpha1.PruneConfig) {
+	s.setResourcesLock.Lock()
+	defer s.setResourcesLock.Unlock()
+
+	expanded, err := s.expandClusterResources(clusterResources)
+	if err != nil {
+		if s.expandedResources != nil && apiequality.Semantic.DeepEqual(s.clusterResources, clusterResources) {
+			// avoid cleaning the resources of the groups that are failed to be discovered
+			klog.ErrorS(err, "Failed to expand wildcard resources, keep the last expanded resources", "cluster", s.name)
+			expanded = s.expandedResources
+		} else {
+			klog.ErrorS(err, "Failed to discover some resources, the wildcard resources are partially expanded", "cluster", s.name)
+		}
+	}
+	s.resourcesSet = true
+	s.clusterResources, s.pruneConfig, s.expandedResources = clusterResources, pruneConfig, expanded
+
+	// The resources that can not be resolved are not treated as deleted if the discovery is failed,
+	// eg. the cluster is unreachable or the discovery of an aggregated api is failed,
+	// otherwise the stored resources would be cleaned.
+	_, _, discoveryErr := s.discovery.ServerGroupsAndResources()
+	if discoveryErr != nil {
+		klog.ErrorS(discoveryErr, "Failed to discover resources, keep the existing resource synchros", "cluster", s.name)
+	}
+
 	// configs key is resource's storage gvk
 	configs := map[schema.GroupVersionResource]*syncConfig{}
 	resourceStatuses := map[schema.GroupResource]*clustersv1alpha1.ClusterResourceStatus{}
-	for _, resources := range clusterResources {
+
+	s.resourcelock.RLock()
+	clusterPaused := s.paused
+	s.resourcelock.RUnlock()
+
+	watchable := s.newWatchableChecker()
+	for _, resources := range expanded {
+		paused := clusterPaused || resources.Paused
+
+		pruner := newResourcePruner(pruneConfig)
+		if resources.Prune != nil {
+			pruner = newResourcePruner(resources.Prune)
+		}
+
 		for _, resource := range resources.Resources {
 			gr := schema.GroupResource{Group: resources.Group, Resource: resource}
 			gvks, err := s.restmapper.KindsFor(gr.WithVersion(""))
@@ -175,25 +354,52 @@ func (s *ClusterSynchro) SetResources(clusterResources []clustersv1alpha1.Cluste
 				continue
 			}
 
+			selector, err := newResourceSelector(resources, mapper.Scope.Name() == meta.RESTScopeNameNamespace)
+			if err != nil {
+				klog.ErrorS(err, "Skip resource sync", "cluster", s.name, "resource", gr)
+				continue
+			}
+
 			// get cluster pedia supported versions
 			gvs := legacyresource.Scheme.VersionsForGroupKind(gvks[0].GroupKind())
 			if len(gvs) == 0 {
-				// TODO(iceber): support custome resource
-				klog.InfoS("Skip resource sync, not support to sync custom resource", "cluster", s.name, "resource", gr)
-				continue
+				// custom resource
+				syncVersions := s.customResourceSyncVersions(resources.Versions, gvks)
+				if len(syncVersions) == 0 {
+					klog.ErrorS(errors.New("Not found sync versions"), "Skip custom resource sync", "cluster", s.name, "resource", gr, "versions", resources.Versions)
+					continue
+				}
 
-				/*
-					// custome resource
-					for _, version := range resources.Versions {
-						gvrs[schema.GroupVersionResource{resources.Group, version, resource}] = struct{}{}
+				info := &clustersv1alpha1.ClusterResourceStatus{
+					Kind:       mapper.GroupVersionKind.Kind,
+					Resource:   gr.Resource,
+					Namespaced: mapper.Scope.Name() == meta.RESTScopeNameNamespace,
+				}
+				for _, version := range syncVersions {
+					// custom resource is stored with the synchronized version
+					syncResource := gr.WithVersion(version)
+					if _, ok := configs[syncResource]; !ok {
+						configs[syncResource] = &syncConfig{
+							kind:            mapper.GroupVersionKind.Kind,
+							syncResource:    syncResource,
+							storageResource: syncResource,
+							storageConfig:   customresource.NewStorageConfig(syncResource),
+							selector:        selector,
+							pruner:          pruner,
+							paused:          paused,
+							watchable:       watchable(syncResource),
+						}
 					}
 
-					resourceStatuses[gr] = ResourceInfo{
-						Kind:       mapper.GroupVersionKind.Kind,
-						Namespaced: mapper.Scope.Name() == meta.RESTScopeNameNamespace,
-					}
-					continue
-				*/
+					info.SyncConditions = append(info.SyncConditions, clustersv1alpha1.ClusterResourceSyncCondition{
+						Version:        version,
+						StorageVersion: version,
+						Status:         clustersv1alpha1.SyncStatusPending,
+						Reason:         "SynchroCreating",
+					})
+				}
+				resourceStatuses[gr] = info
+				continue
 			}
 
 			// kube resource
@@ -225,9 +431,14 @@ func (s *ClusterSynchro) SetResources(clusterResources []clustersv1alpha1.Cluste
 			storageResource := storageConfig.StorageGroupResource.WithVersion(storageConfig.StorageVersion.Version)
 			if _, ok := configs[storageResource]; !ok {
 				config := &syncConfig{
+					kind:            mapper.GroupVersionKind.Kind,
 					syncResource:    syncResource,
 					storageResource: storageResource,
 					storageConfig:   storageConfig,
+					selector:        selector,
+					pruner:          pruner,
+					paused:          paused,
+					watchable:       watchable(syncResource),
 				}
 
 				if syncResource != storageResource {
@@ -259,14 +470,23 @@ func (s *ClusterSynchro) SetResources(clusterResources []clustersv1alpha1.Cluste
 
 	s.resourcelock.Lock()
 	defer s.resourcelock.Unlock()
+
+	if discoveryErr != nil {
+		// keep the statuses of the resources that can not be resolved
+		for gr, status := range s.resourceStatuses.Load().(map[schema.GroupResource]*clustersv1alpha1.ClusterResourceStatus) {
+			if _, ok := resourceStatuses[gr]; !ok {
+				resourceStatuses[gr] = status
+			}
+		}
+	}
 	s.resourceStatuses.Store(resourceStatuses)
 
 	synchros := s.resourceSynchros.Load().(map[schema.GroupVersionResource]*ResourceSynchro)
 
-	// filter deleted resources
+	// filter deleted resources, the resources are removed only if the discovery is succeeded
 	deleted := map[schema.GroupVersionResource]struct{}{}
 	for gvr := range s.resourceVersionCaches {
-		if _, ok := configs[gvr]; !ok {
+		if _, ok := configs[gvr]; !ok && discoveryErr == nil {
 			deleted[gvr] = struct{}{}
 		}
 	}
@@ -277,6 +497,8 @@ func (s *ClusterSynchro) SetResources(clusterResources []clustersv1alpha1.Cluste
 			handler.Close()
 			delete(synchros, gvr)
 		}
+		delete(s.resourceSelectors, gvr)
+		delete(s.syncResources, gvr)
 
 		if err := s.storage.CleanClusterResource(context.TODO(), s.name, gvr); err != nil {
 			klog.ErrorS(err, "Failed to clean cluster resource", "cluster", s.name, "resource", gvr)
@@ -288,8 +510,41 @@ func (s *ClusterSynchro) SetResources(clusterResources []clustersv1alpha1.Cluste
 	}
 
 	for gvr, config := range configs {
-		if _, ok := synchros[gvr]; ok {
-			continue
+		var pollingInterval time.Duration
+		if !config.watchable {
+			pollingInterval = s.options.PollingInterval
+			if s.listConfig.PollingInterval != nil && s.listConfig.PollingInterval.Duration > 0 {
+				pollingInterval = s.listConfig.PollingInterval.Duration
+			}
+		}
+
+		if synchro, ok := synchros[gvr]; ok {
+			// the resource synchro is not rebuilt with the config resolved by the failed discovery
+			if discoveryErr != nil || s.syncResources[gvr] == config.syncResource && s.resourceSelectors[gvr].Equal(config.selector) &&
+				apiequality.Semantic.DeepEqual(synchro.listConfig, s.listConfig) && synchro.pollingInterval == pollingInterval {
+				synchro.SetPruner(config.pruner)
+				if config.paused {
+					synchro.Pause()
+				} else if synchro.Resume() {
+					s.runResourceSynchro(synchro)
+				}
+				continue
+			}
+
+			// The synchronized version, the selector or the list config is changed, rebuild the resource synchro with the same resource version cache,
+			// the resources that are out of the new selector will be deleted when the informer is relisted.
+			klog.InfoS("synchronized version, resource selector or list config is changed, rebuild resource synchro",
+				"cluster", s.name, "resource", gvr, "syncResource", config.syncResource)
+			synchro.Close()
+			synchro.waitInformerStopped()
+			delete(synchros, gvr)
+
+			// the stored resources are rewritten with or without the full objects
+			if s.resourceSelectors[gvr].metadataOnly != config.selector.metadataOnly {
+				if cache, ok := s.resourceVersionCaches[gvr]; ok {
+					cache.ResetVersions()
+				}
+			}
 		}
 
 		resourceStorage, err := s.storage.NewResourceStorage(config.storageConfig)
@@ -306,21 +561,91 @@ func (s *ClusterSynchro) SetResources(clusterResources []clustersv1alpha1.Cluste
 		}
 
 		synchro := newResourceSynchro(s.name,
-			s.listerWatcherFactory.ForResource(metav1.NamespaceAll, config.syncResource),
+			s.newListerWatcher(config.syncResource, config.kind, config.selector),
+			s.listLimiter,
+			s.listConfig,
+			pollingInterval,
 			resourceVersionCache,
 			config.convertor,
 			resourceStorage,
+			s.options,
 		)
-		if s.handlerStopCh != nil {
-			select {
-			case <-s.handlerStopCh:
-			default:
-				go synchro.Run(s.handlerStopCh)
-			}
+		synchro.SetPruner(config.pruner)
+		if config.paused {
+			synchro.Pause()
 		}
+		s.resourceSelectors[gvr] = config.selector
+		s.syncResources[gvr] = config.syncResource
+		s.runResourceSynchro(synchro)
 		synchros[gvr] = synchro
 	}
 	s.resourceSynchros.Store(synchros)
+	select {
+	case <-s.closer:
+		// the metrics of the cluster are deleted after the cluster synchro is shut down
+	default:
+		metrics.ResourceSynchros.WithLabelValues(s.name).Set(float64(len(synchros)))
+	}
+}
+
+// runResourceSynchro runs the resource synchro if the resource synchros of the cluster are running,
+// it should be called with the resourcelock held.
+func (s *ClusterSynchro) runResourceSynchro(synchro *ResourceSynchro) {
+	if s.handlerStopCh == nil {
+		return
+	}
+
+	select {
+	case <-s.handlerStopCh:
+	default:
+		go synchro.Run(s.handlerStopCh)
+	}
+}
+
+// newWatchableChecker returns a func to check if the resource supports watch by the discovery of the cluster,
+// the discovery of a group version is cached in the func, and the resource is considered watchable if the discovery fails.
+func (s *ClusterSynchro) newWatchableChecker() func(gvr schema.GroupVersionResource) bool {
+	resources := make(map[schema.GroupVersion]map[string]bool)
+	return func(gvr schema.GroupVersionResource) bool {
+		gv := gvr.GroupVersion()
+		watchables, ok := resources[gv]
+		if !ok {
+			list, err := s.discovery.ServerResourcesForGroupVersion(gv.String())
+			if err != nil {
+				klog.ErrorS(err, "Failed to discover resources, the resources are considered watchable", "cluster", s.name, "groupVersion", gv)
+			} else {
+				watchables = make(map[string]bool, len(list.APIResources))
+				for _, resource := range list.APIResources {
+					watchables[resource.Name] = sets.NewString(resource.Verbs...).Has("watch")
+				}
+			}
+			resources[gv] = watchables
+		}
+
+		watchable, ok := watchables[gvr.Resource]
+		return !ok || watchable
+	}
+}
+
+// customResourceSyncVersions returns the versions of the custom resource that need to be synchronized,
+// if versions is empty, the cluster's preferred version is used.
+func (s *ClusterSynchro) customResourceSyncVersions(versions []string, gvks []schema.GroupVersionKind) []string {
+	if len(versions) == 0 {
+		return []string{gvks[0].Version}
+	}
+
+	supportedVersions := sets.NewString()
+	for _, gvk := range gvks {
+		supportedVersions.Insert(gvk.Version)
+	}
+
+	var syncVersions []string
+	for _, version := range versions {
+		if supportedVersions.Has(version) {
+			syncVersions = append(syncVersions, version)
+		}
+	}
+	return syncVersions
 }
 
 func (s *ClusterSynchro) Shutdown() {
@@ -334,13 +659,75 @@ func (s *ClusterSynchro) Shutdown() {
 	}
 
 	<-s.closed
+	// the status is not requested by the monitor after the status channel is closed
+	<-s.monitorStopped
 	close(s.status)
+	// the cluster status must not be updated after the cluster synchro is shut down
+	<-s.statusUpdaterStopped
+	s.deleteMetrics()
+}
+
+// GracefulShutdown stops the informers of all resource synchros first,
+// then writes the pending resources to the storage until the ctx is done,
+// and updates the cluster status with the stopped sync conditions at last.
+func (s *ClusterSynchro) GracefulShutdown(ctx context.Context) {
+	s.closeOnce.Do(func() {
+		close(s.closer)
+	})
+
+	s.resourcelock.Lock()
+	synchros := s.resourceSynchros.Load().(map[schema.GroupVersionResource]*ResourceSynchro)
+	var waitGroup sync.WaitGroup
+	for _, handler := range synchros {
+		waitGroup.Add(1)
+		go func(handler *ResourceSynchro) {
+			defer waitGroup.Done()
+			handler.Shutdown(ctx)
+		}(handler)
+	}
+	waitGroup.Wait()
+	s.resourcelock.Unlock()
+
+	<-s.closed
+	<-s.monitorStopped
+	close(s.status)
+	<-s.statusUpdaterStopped
+
+	// the ctx may be done after draining the queues, the final status is updated with a separate timeout
+	updateCtx, cancel := context.WithTimeout(context.Background(), finalStatusUpdateTimeout)
+	defer cancel()
+	// the final status is updated with the latest statistics
+	s.statisticsPublishedAt = time.Time{}
+	status := s.genClusterStatus()
+	if err := s.ClusterStatusUpdater.UpdateClusterStatus(updateCtx, s.name, status); err != nil {
+		metrics.ClusterStatusUpdateFailures.WithLabelValues(s.name).Inc()
+		klog.ErrorS(err, "Failed to update the final cluster status", "cluster", s.name)
+	}
+	s.deleteMetrics()
+	klog.InfoS("cluster synchro is shutdown", "cluster", s.name)
+}
+
+// deleteMetrics deletes all series of the cluster, it is called after the cluster synchro is shut down
+func (s *ClusterSynchro) deleteMetrics() {
+	// the resource synchros gauge is set by SetResources with the resourcelock held
+	s.resourcelock.Lock()
+	defer s.resourcelock.Unlock()
+
+	metrics.ResourceSynchros.DeleteLabelValues(s.name)
+	metrics.ClusterStatusUpdateFailures.DeleteLabelValues(s.name)
+	metrics.ClusterHealthCheckDuration.DeleteLabelValues(s.name)
+	for _, reason := range healthReasons {
+		metrics.ClusterHealthChecks.DeleteLabelValues(s.name, reason)
+	}
 }
 
 func (s *ClusterSynchro) genClusterStatus() *clustersv1alpha1.ClusterStatus {
 	resourceStatuses := s.resourceStatuses.Load().(map[schema.GroupResource]*clustersv1alpha1.ClusterResourceStatus)
 	synchros := s.resourceSynchros.Load().(map[schema.GroupVersionResource]*ResourceSynchro)
 
+	publishStatistics := time.Since(s.statisticsPublishedAt) >= s.options.StatisticsPublishInterval
+	statistics := make(map[schema.GroupVersionResource]resourceStatistics, len(synchros))
+
 	groups := make(map[string]clustersv1alpha1.ClusterGroupStatus)
 	for gr, resourceStatus := range resourceStatuses {
 		groupStatus := groups[gr.Group]
@@ -356,10 +743,26 @@ func (s *ClusterSynchro) genClusterStatus() *clustersv1alpha1.ClusterStatus {
 
 			if synchro, ok := synchros[gvr]; ok {
 				status := synchro.Status()
+				synchro.setQueueDepthMetric(status.QueueDepth)
 				cond.Status = status.Status
 				cond.Reason = status.Reason
 				cond.Message = status.Message
 				cond.LastTransitionTime = status.LastTransitionTime
+				cond.InitialListSynced = status.InitialListSynced
+
+				stats, ok := s.publishedStatistics[gvr]
+				if !ok || publishStatistics || stats.transited(status) {
+					stats = newResourceStatistics(status)
+				}
+				statistics[gvr] = stats
+				cond.StoredResourceCount = stats.storedResourceCount
+				cond.ProcessedEventCount = stats.processedEventCount
+				cond.QueueDepth = stats.queueDepth
+				cond.LastEventTime = stats.lastEventTime
+				cond.LastWriteTime = stats.lastWriteTime
+				cond.FailedResourceCount = status.FailedResourceCount
+				cond.FailedResources = status.FailedResources
+				cond.LastStorageError = status.LastStorageError
 			} else {
 				if cond.Status == "" {
 					cond.Status = clustersv1alpha1.SyncStatusPending
@@ -387,6 +790,11 @@ func (s *ClusterSynchro) genClusterStatus() *clustersv1alpha1.ClusterStatus {
 	}
 	sortClusterGroupStatusByName(groupStatuses)
 
+	if publishStatistics {
+		s.statisticsPublishedAt = time.Now()
+	}
+	s.publishedStatistics = statistics
+
 	version := s.version.Load().(version.Info).GitVersion
 	readyCondition := s.readyCondition.Load().(metav1.Condition)
 	return &clustersv1alpha1.ClusterStatus{
@@ -405,9 +813,12 @@ func (s *ClusterSynchro) updateStatus() {
 }
 
 func (s *ClusterSynchro) clusterStatusUpdater() {
+	defer close(s.statusUpdaterStopped)
+
 	for range s.status {
 		status := s.genClusterStatus()
 		if err := s.ClusterStatusUpdater.UpdateClusterStatus(context.TODO(), s.name, status); err != nil {
+			metrics.ClusterStatusUpdateFailures.WithLabelValues(s.name).Inc()
 			klog.ErrorS(err, "Failed to update cluster status", "cluster", s.name, status.Conditions[0].Reason)
 		}
 	}
@@ -481,6 +892,7 @@ func (synchro *ClusterSynchro) stopResourceSynchro() {
 }
 
 func (synchro *ClusterSynchro) Monitor() {
+	defer close(synchro.monitorStopped)
 	klog.V(2).InfoS("Cluster Synchro Monitor Running...", "cluster", synchro.name)
 
 	wait.JitterUntil(synchro.checkClusterHealthy, 5*time.Second, 0.5, false, synchro.closer)
@@ -488,70 +900,81 @@ func (synchro *ClusterSynchro) Monitor() {
 
 func (synchro *ClusterSynchro) checkClusterHealthy() {
 	lastReadyCondition := synchro.readyCondition.Load().(metav1.Condition)
-	ready, err := checkKubeHealthy(synchro.clusterclient)
-	if ready {
+	start := time.Now()
+	health := checkClusterHealth(synchro.clusterclient.Discovery().RESTClient(), synchro.RESTConfig().Proxy != nil, synchro.options.ClockSkewThreshold)
+	metrics.ClusterHealthCheckDuration.WithLabelValues(synchro.name).Observe(time.Since(start).Seconds())
+	metrics.ClusterHealthChecks.WithLabelValues(synchro.name, health.reason).Inc()
+	if health.ready {
+		synchro.healthCheckFailures = 0
 		synchro.startResourceSynchro()
 
-		if lastReadyCondition.Status != metav1.ConditionTrue {
-			condition := metav1.Condition{
-				Type:               clustersv1alpha1.ClusterConditionReady,
-				Status:             metav1.ConditionTrue,
-				Reason:             "Healthy",
-				LastTransitionTime: metav1.Now(),
-			}
-
-			version, err := synchro.clusterclient.Discovery().ServerVersion()
-			if err != nil {
-				condition.Message = err.Error()
-				klog.ErrorS(err, "Failed to get cluster version", "cluster", synchro.name)
-			} else {
-				synchro.version.Store(*version)
+		// the served resources and versions may be changed when the cluster is upgraded
+		lastVersion := synchro.version.Load().(version.Info)
+		version, err := synchro.clusterclient.Discovery().ServerVersion()
+		if err != nil {
+			klog.ErrorS(err, "Failed to get cluster version", "cluster", synchro.name)
+		} else {
+			if lastVersion.GitVersion != "" && lastVersion.GitVersion != version.GitVersion {
+				klog.InfoS("cluster version is changed", "cluster", synchro.name, "last", lastVersion.GitVersion, "current", version.GitVersion)
+				synchro.triggerDiscoveryRefresh("VersionChanged")
 			}
-
-			synchro.readyCondition.Store(condition)
+			synchro.version.Store(*version)
 		}
 
+		condition := metav1.Condition{
+			Type:    clustersv1alpha1.ClusterConditionReady,
+			Status:  metav1.ConditionTrue,
+			Reason:  health.reason,
+			Message: health.message,
+		}
+		if err != nil && condition.Message == "" {
+			condition.Message = err.Error()
+		}
+		synchro.setReadyCondition(lastReadyCondition, condition)
+		if lastReadyCondition.Status != metav1.ConditionTrue {
+			// the resources that could not be resolved while the cluster was not ready are resolved again
+			synchro.triggerDiscoveryRefresh("ClusterReady")
+		}
 		synchro.updateStatus()
 		return
 	}
 
-	condition := metav1.Condition{
-		Type:   clustersv1alpha1.ClusterConditionReady,
-		Status: metav1.ConditionFalse,
-	}
-	if err == nil {
-		condition.Reason = "Unhealthy"
-		condition.Message = "cluster health responded without ok"
-	} else {
-		condition.Reason = "NotReachable"
-		condition.Message = err.Error()
-	}
-	if lastReadyCondition.Status != condition.Status || lastReadyCondition.Reason != condition.Reason || lastReadyCondition.Message != condition.Message {
-		condition.LastTransitionTime = metav1.Now()
-		synchro.readyCondition.Store(condition)
+	// the Ready condition is not flipped and the resource synchros are not stopped
+	// until the consecutive failures reach the thresholds, so that a flaky check does not stop the synchronization.
+	synchro.healthCheckFailures++
+	klog.V(2).InfoS("cluster health check failed", "cluster", synchro.name, "reason", health.reason, "message", health.message,
+		"consecutive failures", synchro.healthCheckFailures)
+
+	if synchro.healthCheckFailures >= synchro.options.HealthCheckFailureThreshold ||
+		lastReadyCondition.Status != metav1.ConditionTrue {
+		condition := metav1.Condition{
+			Type:    clustersv1alpha1.ClusterConditionReady,
+			Status:  metav1.ConditionFalse,
+			Reason:  health.reason,
+			Message: health.message,
+		}
+		synchro.setReadyCondition(lastReadyCondition, condition)
 	}
 
-	// if the last status was not ConditionTrue, stop resource synchros
-	if lastReadyCondition.Status != metav1.ConditionTrue {
+	if synchro.healthCheckFailures >= synchro.options.HealthCheckStopThreshold {
 		synchro.stopResourceSynchro()
 	}
 
 	synchro.updateStatus()
 }
 
-// TODO(iceber): resolve for more detailed error
-func checkKubeHealthy(client kubernetes.Interface) (bool, error) {
-	ctx, cancel := context.WithTimeout(context.TODO(), 5*time.Second)
-	defer cancel()
-
-	_, err := client.Discovery().RESTClient().Get().AbsPath("/readyz").DoRaw(ctx)
-	if apierrors.IsNotFound(err) {
-		_, err = client.Discovery().RESTClient().Get().AbsPath("/healthz").DoRaw(ctx)
+// setReadyCondition stores the Ready condition if it is changed,
+// the last transition time is updated only if the status or the reason is changed.
+func (synchro *ClusterSynchro) setReadyCondition(last, condition metav1.Condition) {
+	if last.Status == condition.Status && last.Reason == condition.Reason && last.Message == condition.Message {
+		return
 	}
-	if err != nil {
-		return false, err
+
+	condition.LastTransitionTime = last.LastTransitionTime
+	if last.Status != condition.Status || last.Reason != condition.Reason {
+		condition.LastTransitionTime = metav1.Now()
 	}
-	return true, nil
+	synchro.readyCondition.Store(condition)
 }
 
 func sortClusterGroupStatusByName(statuses []clustersv1alpha1.ClusterGroupStatus) {
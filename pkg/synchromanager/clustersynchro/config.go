@@ -0,0 +1,54 @@
+package clustersynchro
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"k8s.io/client-go/rest"
+)
+
+// ConfigEqual returns true if the configs of the cluster are equal,
+// the proxy funcs can not be compared directly, so they are compared by the proxy URLs.
+func ConfigEqual(a, b *rest.Config) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if !proxyEqual(a.Proxy, b.Proxy) {
+		return false
+	}
+
+	a, b = rest.CopyConfig(a), rest.CopyConfig(b)
+	a.Proxy, b.Proxy = nil, nil
+	return reflect.DeepEqual(a, b)
+}
+
+func proxyEqual(a, b func(*http.Request) (*url.URL, error)) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	req := &http.Request{URL: &url.URL{}}
+	aURL, aErr := a(req)
+	bURL, bErr := b(req)
+	if aErr != nil || bErr != nil {
+		return false
+	}
+	if aURL == nil || bURL == nil {
+		return aURL == bURL
+	}
+	return aURL.String() == bURL.String()
+}
+
+// isProxyError returns true if the err is returned when connecting to the proxy,
+// the HTTP CONNECT proxy returns `proxyconnect` errors and the SOCKS5 proxy returns `socks connect` errors.
+func isProxyError(err error) bool {
+	var opErr *net.OpError
+	if !errors.As(err, &opErr) {
+		return false
+	}
+	return opErr.Op == "proxyconnect" || strings.HasPrefix(opErr.Op, "socks")
+}
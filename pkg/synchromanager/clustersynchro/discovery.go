@@ -0,0 +1,118 @@
+package clustersynchro
+
+import (
+	"context"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/metadata"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+)
+
+// discoveryRefreshDelay is how long to wait for more refresh triggers before refreshing the discovery,
+// the CRD events are usually received in bursts and the new CRDs take a while to be served.
+const discoveryRefreshDelay = 5 * time.Second
+
+var crdResource = schema.GroupVersionResource{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"}
+
+// triggerDiscoveryRefresh requests the discovery refresher to refresh the discovery of the cluster
+func (s *ClusterSynchro) triggerDiscoveryRefresh(reason string) {
+	select {
+	case s.discoveryRefreshCh <- struct{}{}:
+		klog.V(2).InfoS("discovery refresh is triggered", "cluster", s.name, "reason", reason)
+	default:
+	}
+}
+
+// discoveryRefresher refreshes the discovery of the cluster periodically or when it is triggered,
+// and re-resolves the synchronized resources with the latest discovery.
+func (s *ClusterSynchro) discoveryRefresher() {
+	ticker := time.NewTicker(s.options.DiscoveryRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.closer:
+			return
+		case <-ticker.C:
+		case <-s.discoveryRefreshCh:
+			select {
+			case <-s.closer:
+				return
+			case <-time.After(discoveryRefreshDelay):
+			}
+
+			// the triggers received during the delay are merged
+			select {
+			case <-s.discoveryRefreshCh:
+			default:
+			}
+		}
+
+		s.refreshResources()
+	}
+}
+
+// refreshResources invalidates the cached discovery and sets the resources again,
+// the resource synchros are started, stopped or rebuilt with the new preferred versions as needed.
+func (s *ClusterSynchro) refreshResources() {
+	// the discovery can not be refreshed if the cluster is not ready
+	if s.readyCondition.Load().(metav1.Condition).Status != metav1.ConditionTrue {
+		klog.V(2).InfoS("cluster is not ready, skip refreshing the discovery", "cluster", s.name)
+		return
+	}
+
+	s.restmapper.Reset()
+
+	s.setResourcesLock.Lock()
+	resourcesSet, clusterResources, pruneConfig := s.resourcesSet, s.clusterResources, s.pruneConfig
+	s.setResourcesLock.Unlock()
+
+	// the resource versions loaded from the storage would be cleaned if the resources are not set yet
+	if !resourcesSet {
+		return
+	}
+
+	klog.V(2).InfoS("refresh the resources with the latest discovery", "cluster", s.name)
+	s.SetResources(clusterResources, pruneConfig)
+}
+
+// runCRDWatcher watches the metadata of the CRDs in the cluster,
+// the discovery is refreshed when the CRDs are created, updated or deleted.
+func (s *ClusterSynchro) runCRDWatcher(client metadata.Interface) {
+	lw := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			return client.Resource(crdResource).List(context.TODO(), options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			return client.Resource(crdResource).Watch(context.TODO(), options)
+		},
+	}
+
+	crdInformer := cache.NewSharedIndexInformer(lw, &metav1.PartialObjectMetadata{}, 0, cache.Indexers{})
+	crdInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			// the CRDs of the initial list are already in the discovery
+			if !crdInformer.HasSynced() {
+				return
+			}
+			s.triggerDiscoveryRefresh("CRDAdded")
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldCRD, newCRD := oldObj.(*metav1.PartialObjectMetadata), newObj.(*metav1.PartialObjectMetadata)
+			// only the changes of the spec may change the served versions
+			if oldCRD.Generation == newCRD.Generation {
+				return
+			}
+			s.triggerDiscoveryRefresh("CRDUpdated")
+		},
+		DeleteFunc: func(obj interface{}) {
+			s.triggerDiscoveryRefresh("CRDDeleted")
+		},
+	})
+	crdInformer.Run(s.closer)
+}
@@ -0,0 +1,27 @@
+package clustersynchro
+
+// the reasons of the failures to create the cluster synchro,
+// the reasons of the health check are also returned if the cluster is not reachable.
+const (
+	CreationReasonInvalidCredentials = "InvalidCredentials"
+	CreationReasonStorageUnavailable = "StorageUnavailable"
+)
+
+// CreationError is returned by New when the cluster synchro can not be created,
+// the reason is reported in the Ready condition of the cluster.
+type CreationError struct {
+	Reason string
+
+	// Retryable is true if the creation may succeed later without changing the cluster
+	Retryable bool
+
+	Err error
+}
+
+func (e *CreationError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *CreationError) Unwrap() error {
+	return e.Err
+}
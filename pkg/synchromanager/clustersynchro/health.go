@@ -0,0 +1,178 @@
+package clustersynchro
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"k8s.io/client-go/rest"
+)
+
+// the reasons of the cluster's Ready condition
+const (
+	HealthReasonHealthy           = "Healthy"
+	HealthReasonClockSkew         = "ClockSkew"
+	HealthReasonUnhealthy         = "Unhealthy"
+	HealthReasonReadyzCheckFailed = "ReadyzCheckFailed"
+	HealthReasonUnauthorized      = "Unauthorized"
+	HealthReasonForbidden         = "Forbidden"
+	HealthReasonDNSFailure        = "DNSFailure"
+	HealthReasonTimeout           = "Timeout"
+	HealthReasonTLSVerifyFailed   = "TLSVerificationFailed"
+	HealthReasonProxyUnreachable  = "ProxyUnreachable"
+	HealthReasonNotReachable      = "NotReachable"
+)
+
+// healthReasons are all reasons of the health checks, they are the values of the result label of the health check metrics
+var healthReasons = []string{
+	HealthReasonHealthy, HealthReasonClockSkew, HealthReasonUnhealthy, HealthReasonReadyzCheckFailed,
+	HealthReasonUnauthorized, HealthReasonForbidden, HealthReasonDNSFailure, HealthReasonTimeout,
+	HealthReasonTLSVerifyFailed, HealthReasonProxyUnreachable, HealthReasonNotReachable,
+}
+
+const (
+	healthCheckTimeout = 5 * time.Second
+
+	maxHealthResponseBodySize = 64 * 1024
+)
+
+// clusterHealth is the result of a health check of the cluster
+type clusterHealth struct {
+	ready   bool
+	reason  string
+	message string
+}
+
+// checkClusterHealth requests the `/readyz` of the cluster, or the `/healthz` if the `/readyz` is not found,
+// and classifies the failure into the reason of the Ready condition.
+func checkClusterHealth(client rest.Interface, usingProxy bool, clockSkewThreshold time.Duration) clusterHealth {
+	restClient, ok := client.(*rest.RESTClient)
+	if !ok || restClient.Client == nil {
+		return clusterHealth{reason: HealthReasonUnhealthy, message: "the rest client of the cluster does not support the health check"}
+	}
+
+	ctx, cancel := context.WithTimeout(context.TODO(), healthCheckTimeout)
+	defer cancel()
+
+	resp, body, err := doHealthRequest(ctx, restClient, "/readyz")
+	if err == nil && resp.StatusCode == http.StatusNotFound {
+		resp, body, err = doHealthRequest(ctx, restClient, "/healthz")
+	}
+	if err != nil {
+		return clusterHealth{reason: classifyHealthError(err, usingProxy), message: err.Error()}
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized:
+		return clusterHealth{reason: HealthReasonUnauthorized, message: "the credentials of the cluster are invalid or expired"}
+	case http.StatusForbidden:
+		return clusterHealth{reason: HealthReasonForbidden, message: "the credentials of the cluster are not allowed to check the health"}
+	default:
+		if failed := failedReadyzChecks(body); len(failed) != 0 {
+			return clusterHealth{
+				reason:  HealthReasonReadyzCheckFailed,
+				message: fmt.Sprintf("readyz checks failed: %s", strings.Join(failed, ", ")),
+			}
+		}
+		return clusterHealth{
+			reason:  HealthReasonUnhealthy,
+			message: fmt.Sprintf("cluster health responded with %d: %s", resp.StatusCode, strings.TrimSpace(string(body))),
+		}
+	}
+
+	// the tokens and certificates may be considered as expired or not yet valid if the clocks are skewed
+	if date, err := http.ParseTime(resp.Header.Get("Date")); err == nil && clockSkewThreshold > 0 {
+		skew := time.Since(date)
+		if skew < 0 {
+			skew = -skew
+		}
+		// the Date header is in seconds, so the skew less than a second is ignored
+		if skew > clockSkewThreshold+time.Second {
+			return clusterHealth{
+				ready:   true,
+				reason:  HealthReasonClockSkew,
+				message: fmt.Sprintf("the clock of the cluster is skewed more than %s", clockSkewThreshold),
+			}
+		}
+	}
+	return clusterHealth{ready: true, reason: HealthReasonHealthy}
+}
+
+func doHealthRequest(ctx context.Context, client *rest.RESTClient, path string) (*http.Response, []byte, error) {
+	// the verbose response lists the individual checks
+	url := client.Get().AbsPath(path).Param("verbose", "true").URL()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url.String(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := client.Client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxHealthResponseBodySize))
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, body, nil
+}
+
+// failedReadyzChecks returns the names of the failed checks in the verbose response,
+// the failed check is listed as `[-]etcd failed: reason withheld`.
+func failedReadyzChecks(body []byte) []string {
+	var failed []string
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "[-]") {
+			continue
+		}
+
+		name := strings.TrimPrefix(line, "[-]")
+		if i := strings.IndexByte(name, ' '); i > 0 {
+			name = name[:i]
+		}
+		failed = append(failed, name)
+	}
+	return failed
+}
+
+func classifyHealthError(err error, usingProxy bool) string {
+	if usingProxy && isProxyError(err) {
+		return HealthReasonProxyUnreachable
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return HealthReasonDNSFailure
+	}
+
+	var (
+		unknownAuthorityErr x509.UnknownAuthorityError
+		hostnameErr         x509.HostnameError
+		invalidErr          x509.CertificateInvalidError
+		recordHeaderErr     tls.RecordHeaderError
+	)
+	if errors.As(err, &unknownAuthorityErr) || errors.As(err, &hostnameErr) ||
+		errors.As(err, &invalidErr) || errors.As(err, &recordHeaderErr) {
+		return HealthReasonTLSVerifyFailed
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return HealthReasonTimeout
+	}
+	return HealthReasonNotReachable
+}
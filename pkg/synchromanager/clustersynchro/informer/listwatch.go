@@ -3,12 +3,18 @@ package informer
 import (
 	"context"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/metadata"
 	"k8s.io/client-go/tools/cache"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/apis/pedia"
 )
 
 type TweakListOptionsFunc func(*metav1.ListOptions)
@@ -53,3 +59,166 @@ func (f *listerWatcherFactory) ForResourceWithOptions(namespace string, gvr sche
 		},
 	}
 }
+
+func NewMetadataListWatcherFactory(client metadata.Interface) DynamicListerWatcherFactory {
+	return &metadataListerWatcherFactory{client}
+}
+
+// metadataListerWatcherFactory lists and watches the PartialObjectMetadata of the resources
+type metadataListerWatcherFactory struct {
+	client metadata.Interface
+}
+
+func (f *metadataListerWatcherFactory) ForResource(namespace string, gvr schema.GroupVersionResource) cache.ListerWatcher {
+	return f.ForResourceWithOptions(namespace, gvr, nil)
+}
+
+func (f *metadataListerWatcherFactory) ForResourceWithOptions(namespace string, gvr schema.GroupVersionResource, tweakListOptions TweakListOptionsFunc) cache.ListerWatcher {
+	return &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			if tweakListOptions != nil {
+				tweakListOptions(&options)
+			}
+			return f.client.Resource(gvr).Namespace(namespace).List(context.TODO(), options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			if tweakListOptions != nil {
+				tweakListOptions(&options)
+			}
+			return f.client.Resource(gvr).Namespace(namespace).Watch(context.TODO(), options)
+		},
+	}
+}
+
+// NewMetadataOnlyListerWatcher converts the PartialObjectMetadata listed and watched by lw
+// to the unstructured objects of the kind, which only contain the metadata of the resources
+// and are marked with the `shadow.clusterpedia.io/metadata-only` annotation.
+func NewMetadataOnlyListerWatcher(lw cache.ListerWatcher, gvk schema.GroupVersionKind) cache.ListerWatcher {
+	convert := func(obj runtime.Object) (runtime.Object, error) {
+		partial, ok := obj.(*metav1.PartialObjectMetadata)
+		if !ok {
+			return obj, nil
+		}
+
+		metadata := partial.ObjectMeta.DeepCopy()
+		annotations := metadata.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string, 1)
+		}
+		annotations[pedia.ShadowAnnotationMetadataOnly] = "true"
+		metadata.SetAnnotations(annotations)
+
+		object, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&metav1.PartialObjectMetadata{ObjectMeta: *metadata})
+		if err != nil {
+			return nil, err
+		}
+		u := &unstructured.Unstructured{Object: object}
+		u.SetGroupVersionKind(gvk)
+		return u, nil
+	}
+
+	return &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			list, err := lw.List(options)
+			if err != nil {
+				return nil, err
+			}
+
+			partials, ok := list.(*metav1.PartialObjectMetadataList)
+			if !ok {
+				return list, nil
+			}
+
+			ulist := &unstructured.UnstructuredList{}
+			ulist.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+			ulist.SetResourceVersion(partials.GetResourceVersion())
+			ulist.SetContinue(partials.GetContinue())
+			ulist.SetRemainingItemCount(partials.GetRemainingItemCount())
+			ulist.Items = make([]unstructured.Unstructured, 0, len(partials.Items))
+			for i := range partials.Items {
+				obj, err := convert(&partials.Items[i])
+				if err != nil {
+					return nil, err
+				}
+				ulist.Items = append(ulist.Items, *obj.(*unstructured.Unstructured))
+			}
+			return ulist, nil
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			w, err := lw.Watch(options)
+			if err != nil {
+				return nil, err
+			}
+
+			return watch.Filter(w, func(event watch.Event) (watch.Event, bool) {
+				if event.Type == watch.Error {
+					return event, true
+				}
+
+				obj, err := convert(event.Object)
+				if err != nil {
+					return watch.Event{Type: watch.Error, Object: &apierrors.NewInternalError(err).ErrStatus}, true
+				}
+				event.Object = obj
+				return event, true
+			}), nil
+		},
+	}
+}
+
+// NewNamespacesFilteredListerWatcher returns a ListerWatcher that only lists and watches
+// the objects in the given namespaces, the objects are filtered on the client side.
+func NewNamespacesFilteredListerWatcher(lw cache.ListerWatcher, namespaces []string) cache.ListerWatcher {
+	set := make(map[string]struct{}, len(namespaces))
+	for _, namespace := range namespaces {
+		set[namespace] = struct{}{}
+	}
+
+	inNamespaces := func(obj runtime.Object) bool {
+		accessor, err := meta.Accessor(obj)
+		if err != nil {
+			return false
+		}
+		_, ok := set[accessor.GetNamespace()]
+		return ok
+	}
+
+	return &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			list, err := lw.List(options)
+			if err != nil {
+				return nil, err
+			}
+
+			items, err := meta.ExtractList(list)
+			if err != nil {
+				return nil, err
+			}
+
+			filtered := make([]runtime.Object, 0, len(items))
+			for _, item := range items {
+				if inNamespaces(item) {
+					filtered = append(filtered, item)
+				}
+			}
+			if err := meta.SetList(list, filtered); err != nil {
+				return nil, err
+			}
+			return list, nil
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			w, err := lw.Watch(options)
+			if err != nil {
+				return nil, err
+			}
+
+			return watch.Filter(w, func(event watch.Event) (watch.Event, bool) {
+				switch event.Type {
+				case watch.Bookmark, watch.Error:
+					return event, true
+				}
+				return event, inNamespaces(event.Object)
+			}), nil
+		},
+	}
+}
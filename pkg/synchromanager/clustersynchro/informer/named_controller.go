@@ -15,7 +15,6 @@ type controller struct {
 
 	reflectorMutex sync.RWMutex
 	reflector      *cache.Reflector
-	queue          cache.Queue
 }
 
 func NewNamedController(name string, config *cache.Config) cache.Controller {
@@ -71,10 +70,10 @@ func (c *controller) HasSynced() bool {
 	c.reflectorMutex.RLock()
 	defer c.reflectorMutex.RUnlock()
 
-	if c.queue == nil {
+	if c.config.Queue == nil {
 		return false
 	}
-	return c.queue.HasSynced()
+	return c.config.Queue.HasSynced()
 }
 
 func (c *controller) LastSyncResourceVersion() string {
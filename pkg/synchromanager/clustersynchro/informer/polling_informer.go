@@ -0,0 +1,39 @@
+package informer
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+)
+
+// pollingInformer relists the resources on the interval for the resources that can not be watched,
+// such as the resources of some aggregated apis. The listed objects are compared with the
+// ResourceVersionStorage to find the added, updated and deleted objects.
+type pollingInformer struct {
+	*streamingInformer
+
+	interval time.Duration
+}
+
+func NewPollingResourceVersionInformer(name string, lw cache.ListerWatcher, storage *ResourceVersionStorage, pageSize int64, interval time.Duration,
+	handler ResourceEventHandler, waitForCapacity func(stopCh <-chan struct{}),
+) ResourceVersionInformer {
+	if interval <= 0 {
+		panic("polling interval must be greater than 0")
+	}
+
+	return &pollingInformer{
+		streamingInformer: NewStreamingResourceVersionInformer(name, lw, storage, pageSize, handler, waitForCapacity).(*streamingInformer),
+		interval:          interval,
+	}
+}
+
+func (informer *pollingInformer) Run(stopCh <-chan struct{}) {
+	wait.Until(func() {
+		if err := informer.list(stopCh); err != nil {
+			klog.ErrorS(err, "Failed to poll resources", "informer", informer.name)
+		}
+	}, informer.interval, stopCh)
+}
@@ -19,7 +19,9 @@ type resourceVersionInformer struct {
 	listerWatcher cache.ListerWatcher
 }
 
-func NewResourceVersionInformer(name string, lw cache.ListerWatcher, storage *ResourceVersionStorage, exampleObject runtime.Object, handler ResourceEventHandler) ResourceVersionInformer {
+// NewResourceVersionInformer returns the informer that lists the resources in pages if the pageSize is greater than 0,
+// the whole list is still held in the DeltaFIFO until it is handled.
+func NewResourceVersionInformer(name string, lw cache.ListerWatcher, storage *ResourceVersionStorage, exampleObject runtime.Object, handler ResourceEventHandler, pageSize int64) ResourceVersionInformer {
 	if name == "" {
 		panic("name is required")
 	}
@@ -36,6 +38,8 @@ func NewResourceVersionInformer(name string, lw cache.ListerWatcher, storage *Re
 		ListerWatcher: lw,
 		ObjectType:    exampleObject,
 		RetryOnError:  false,
+
+		WatchListPageSize: pageSize,
 		Process: func(obj interface{}) error {
 			deltas := obj.(cache.Deltas)
 			return informer.HandleDeltas(deltas)
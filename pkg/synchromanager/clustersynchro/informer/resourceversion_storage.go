@@ -1,6 +1,9 @@
 package informer
 
 import (
+	"sync"
+	"sync/atomic"
+
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/client-go/tools/cache"
 )
@@ -9,6 +12,10 @@ type ResourceVersionStorage struct {
 	keyFunc cache.KeyFunc
 
 	cacheStorage cache.ThreadSafeStore
+
+	// the writes are serialized to count the keys, so that Len does not list the keys
+	lock  sync.Mutex
+	count int64
 }
 
 var _ cache.KeyListerGetter = &ResourceVersionStorage{}
@@ -30,6 +37,11 @@ func (c *ResourceVersionStorage) Add(obj interface{}) error {
 		return err
 	}
 
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if _, exists := c.cacheStorage.Get(key); !exists {
+		atomic.AddInt64(&c.count, 1)
+	}
 	c.cacheStorage.Add(key, accessor.GetResourceVersion())
 	return nil
 }
@@ -44,6 +56,11 @@ func (c *ResourceVersionStorage) Update(obj interface{}) error {
 		return err
 	}
 
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if _, exists := c.cacheStorage.Get(key); !exists {
+		atomic.AddInt64(&c.count, 1)
+	}
 	c.cacheStorage.Update(key, accessor.GetResourceVersion())
 	return nil
 }
@@ -54,6 +71,11 @@ func (c *ResourceVersionStorage) Delete(obj interface{}) error {
 		return cache.KeyError{Obj: obj, Err: err}
 	}
 
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if _, exists := c.cacheStorage.Get(key); exists {
+		atomic.AddInt64(&c.count, -1)
+	}
 	c.cacheStorage.Delete(key)
 	return nil
 }
@@ -74,12 +96,32 @@ func (c *ResourceVersionStorage) ListKeys() []string {
 	return c.cacheStorage.ListKeys()
 }
 
+func (c *ResourceVersionStorage) Len() int {
+	return int(atomic.LoadInt64(&c.count))
+}
+
 func (c *ResourceVersionStorage) GetByKey(key string) (item interface{}, exists bool, err error) {
 	item, exists = c.cacheStorage.Get(key)
 	return item, exists, nil
 }
 
 func (c *ResourceVersionStorage) Replace(versions map[string]interface{}) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
 	c.cacheStorage.Replace(versions, "")
+	atomic.StoreInt64(&c.count, int64(len(versions)))
 	return nil
 }
+
+// ResetVersions clears the versions of all keys, so that all listed objects are updated,
+// and the objects that are not listed are still deleted.
+func (c *ResourceVersionStorage) ResetVersions() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	keys := c.cacheStorage.ListKeys()
+	versions := make(map[string]interface{}, len(keys))
+	for _, key := range keys {
+		versions[key] = ""
+	}
+	c.cacheStorage.Replace(versions, "")
+}
@@ -0,0 +1,269 @@
+package informer
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+)
+
+const minWatchTimeout = 5 * time.Minute
+
+// streamingInformer lists the resources page by page, and the objects of each page are
+// handled directly instead of being held in the DeltaFIFO until the whole list is received,
+// only the keys of the listed objects are kept to find the deleted objects after the list.
+type streamingInformer struct {
+	name          string
+	storage       *ResourceVersionStorage
+	handler       ResourceEventHandler
+	listerWatcher cache.ListerWatcher
+	pageSize      int64
+
+	// waitForCapacity blocks until the handled objects are consumed,
+	// it is called before listing the next page.
+	waitForCapacity func(stopCh <-chan struct{})
+
+	lock            sync.RWMutex
+	synced          bool
+	resourceVersion string
+}
+
+func NewStreamingResourceVersionInformer(name string, lw cache.ListerWatcher, storage *ResourceVersionStorage, pageSize int64,
+	handler ResourceEventHandler, waitForCapacity func(stopCh <-chan struct{}),
+) ResourceVersionInformer {
+	if name == "" {
+		panic("name is required")
+	}
+	if pageSize <= 0 {
+		panic("page size must be greater than 0")
+	}
+
+	return &streamingInformer{
+		name:            name,
+		storage:         storage,
+		handler:         handler,
+		listerWatcher:   lw,
+		pageSize:        pageSize,
+		waitForCapacity: waitForCapacity,
+	}
+}
+
+func (informer *streamingInformer) HasSynced() bool {
+	informer.lock.RLock()
+	defer informer.lock.RUnlock()
+	return informer.synced
+}
+
+func (informer *streamingInformer) Run(stopCh <-chan struct{}) {
+	backoff := wait.NewExponentialBackoffManager(800*time.Millisecond, 30*time.Second, 2*time.Minute, 2.0, 1.0, clock.RealClock{})
+	wait.BackoffUntil(func() {
+		if err := informer.listAndWatch(stopCh); err != nil {
+			klog.ErrorS(err, "Failed to list and watch resources", "informer", informer.name)
+		}
+	}, backoff, true, stopCh)
+}
+
+func (informer *streamingInformer) listAndWatch(stopCh <-chan struct{}) error {
+	if err := informer.list(stopCh); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-stopCh:
+			return nil
+		default:
+		}
+
+		timeoutSeconds := int64(minWatchTimeout.Seconds() * (rand.Float64() + 1.0))
+		w, err := informer.listerWatcher.Watch(metav1.ListOptions{
+			ResourceVersion:     informer.getResourceVersion(),
+			TimeoutSeconds:      &timeoutSeconds,
+			AllowWatchBookmarks: true,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to watch: %w", err)
+		}
+
+		if err := informer.handleWatch(w, stopCh); err != nil {
+			if apierrors.IsResourceExpired(err) || apierrors.IsGone(err) {
+				// relist by the next loop
+				klog.V(2).InfoS("watch is expired, relist resources", "informer", informer.name)
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+func (informer *streamingInformer) list(stopCh <-chan struct{}) error {
+	listed := sets.NewString()
+	options := metav1.ListOptions{Limit: informer.pageSize}
+	for {
+		select {
+		case <-stopCh:
+			return nil
+		default:
+		}
+
+		list, err := informer.listerWatcher.List(options)
+		if err != nil {
+			if options.Continue != "" && apierrors.IsResourceExpired(err) {
+				// the continue token is expired, restart the list
+				klog.V(2).InfoS("list continue token is expired, restart list", "informer", informer.name)
+				listed = sets.NewString()
+				options.Continue = ""
+				continue
+			}
+			return fmt.Errorf("failed to list: %w", err)
+		}
+
+		listMeta, err := meta.ListAccessor(list)
+		if err != nil {
+			return err
+		}
+
+		if err := meta.EachListItem(list, func(obj runtime.Object) error {
+			key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+			if err != nil {
+				return err
+			}
+			listed.Insert(key)
+			return informer.handleObject(obj, true)
+		}); err != nil {
+			return err
+		}
+
+		if listMeta.GetContinue() == "" {
+			informer.setResourceVersion(listMeta.GetResourceVersion())
+			break
+		}
+		options.Continue = listMeta.GetContinue()
+
+		if informer.waitForCapacity != nil {
+			informer.waitForCapacity(stopCh)
+		}
+	}
+
+	// the objects that are not listed have been deleted
+	for _, key := range informer.storage.ListKeys() {
+		if listed.Has(key) {
+			continue
+		}
+
+		version, exists, _ := informer.storage.GetByKey(key)
+		if !exists {
+			continue
+		}
+		obj := cache.DeletedFinalStateUnknown{Key: key, Obj: version}
+		if err := informer.storage.Delete(obj); err != nil {
+			return err
+		}
+		informer.handler.OnDelete(obj)
+	}
+
+	informer.lock.Lock()
+	informer.synced = true
+	informer.lock.Unlock()
+	return nil
+}
+
+func (informer *streamingInformer) handleWatch(w watch.Interface, stopCh <-chan struct{}) error {
+	defer w.Stop()
+
+	for {
+		select {
+		case <-stopCh:
+			return nil
+		case event, ok := <-w.ResultChan():
+			if !ok {
+				return nil
+			}
+
+			switch event.Type {
+			case watch.Error:
+				return apierrors.FromObject(event.Object)
+			case watch.Added, watch.Modified:
+				if err := informer.handleObject(event.Object, false); err != nil {
+					return err
+				}
+			case watch.Deleted:
+				if err := informer.storage.Delete(event.Object); err != nil {
+					return err
+				}
+				informer.handler.OnDelete(event.Object)
+			case watch.Bookmark:
+			default:
+				continue
+			}
+
+			if accessor, err := meta.Accessor(event.Object); err == nil {
+				informer.setResourceVersion(accessor.GetResourceVersion())
+			}
+		}
+	}
+}
+
+// handleObject has the same logic as resourceVersionInformer.HandleDeltas
+func (informer *streamingInformer) handleObject(obj interface{}, listed bool) error {
+	version, exists, err := informer.storage.Get(obj)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		if err := informer.storage.Add(obj); err != nil {
+			return err
+		}
+		informer.handler.OnAdd(obj)
+		return nil
+	}
+
+	// the objects without the resource version can not be compared,
+	// eg. the metrics of the metrics.k8s.io, they are always updated.
+	if listed && hasResourceVersion(obj) {
+		if v := compareResourceVersion(obj, version); v <= 0 {
+			if v == 0 {
+				informer.handler.OnSync(obj)
+			}
+			return nil
+		}
+	}
+
+	if err := informer.storage.Update(obj); err != nil {
+		return err
+	}
+	informer.handler.OnUpdate(nil, obj)
+	return nil
+}
+
+func hasResourceVersion(obj interface{}) bool {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return false
+	}
+	return accessor.GetResourceVersion() != ""
+}
+
+func (informer *streamingInformer) getResourceVersion() string {
+	informer.lock.RLock()
+	defer informer.lock.RUnlock()
+	return informer.resourceVersion
+}
+
+func (informer *streamingInformer) setResourceVersion(rv string) {
+	informer.lock.Lock()
+	defer informer.lock.Unlock()
+	informer.resourceVersion = rv
+}
@@ -0,0 +1,125 @@
+package clustersynchro
+
+import (
+	"sync"
+)
+
+// listLimiter limits the number of the concurrent list requests to the cluster,
+// the limit can be changed without rebuilding the lister watchers.
+type listLimiter struct {
+	lock    sync.Mutex
+	limit   int
+	running int
+
+	// released is closed and replaced when the running list requests are released or the limit is changed,
+	// so that the waiting acquirers can be canceled by their stop channels.
+	released chan struct{}
+}
+
+func newListLimiter(limit int) *listLimiter {
+	return &listLimiter{limit: limit, released: make(chan struct{})}
+}
+
+// Acquire blocks until the list request is allowed or the stopCh is closed,
+// it returns false if the stopCh is closed, and Release must not be called.
+func (l *listLimiter) Acquire(stopCh <-chan struct{}) bool {
+	for {
+		l.lock.Lock()
+		if l.limit <= 0 || l.running < l.limit {
+			l.running++
+			l.lock.Unlock()
+			return true
+		}
+		released := l.released
+		l.lock.Unlock()
+
+		select {
+		case <-released:
+		case <-stopCh:
+			return false
+		}
+	}
+}
+
+func (l *listLimiter) Release() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.running--
+	l.broadcastLocked()
+}
+
+// SetLimit sets the max number of the concurrent list requests, 0 means no limit
+func (l *listLimiter) SetLimit(limit int) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.limit = limit
+	l.broadcastLocked()
+}
+
+func (l *listLimiter) broadcastLocked() {
+	close(l.released)
+	l.released = make(chan struct{})
+}
+
+// relistSlot holds a slot of the list limiter across the pages of a relist,
+// so that a paginated relist is not interleaved with the pages of other relists.
+type relistSlot struct {
+	limiter *listLimiter
+
+	lock    sync.Mutex
+	held    bool
+	stopped bool
+}
+
+func newRelistSlot(limiter *listLimiter) *relistSlot {
+	return &relistSlot{limiter: limiter}
+}
+
+// Acquire acquires the slot if it is not held, it is called by the informer sequentially.
+// It returns false if the stopCh is closed or the slot is stopped.
+func (s *relistSlot) Acquire(stopCh <-chan struct{}) bool {
+	s.lock.Lock()
+	held := s.held
+	s.lock.Unlock()
+	if held {
+		return true
+	}
+
+	if !s.limiter.Acquire(stopCh) {
+		return false
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.stopped {
+		s.limiter.Release()
+		return false
+	}
+	s.held = true
+	return true
+}
+
+// Release releases the slot if it is held
+func (s *relistSlot) Release() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.held {
+		s.held = false
+		s.limiter.Release()
+	}
+}
+
+// Stop releases the slot and prevents it from being acquired again
+func (s *relistSlot) Stop() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.stopped = true
+	if s.held {
+		s.held = false
+		s.limiter.Release()
+	}
+}
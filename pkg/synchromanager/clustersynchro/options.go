@@ -0,0 +1,85 @@
+package clustersynchro
+
+import (
+	"time"
+)
+
+const (
+	DefaultBatchSize          = 100
+	DefaultBatchFlushInterval = 100 * time.Millisecond
+
+	// DefaultClientQPS and DefaultClientBurst are the same as the defaults of client-go
+	DefaultClientQPS   = 5
+	DefaultClientBurst = 10
+
+	DefaultPollingInterval = time.Minute
+
+	DefaultDiscoveryRefreshInterval = 5 * time.Minute
+
+	DefaultStatisticsPublishInterval = time.Minute
+
+	DefaultHealthCheckFailureThreshold = 3
+	DefaultHealthCheckStopThreshold    = 6
+	DefaultClockSkewThreshold          = time.Minute
+)
+
+// Options are the options shared by all cluster synchros
+type Options struct {
+	// BatchSize is the max number of resources written to the storage in a batch
+	BatchSize int
+
+	// BatchFlushInterval is how long to wait for more resources when the batch is not full
+	BatchFlushInterval time.Duration
+
+	// ClientQPS and ClientBurst are the default rate limits of the clients to the clusters,
+	// they are used if the rate limits are not set in the cluster spec.
+	ClientQPS   float32
+	ClientBurst int
+
+	// MaxConcurrentLists is the default max number of the concurrent list requests to a cluster,
+	// 0 means no limit.
+	MaxConcurrentLists int
+
+	// PollingInterval is the default interval of relisting the resources that can not be watched,
+	// it is used if the polling interval is not set in the cluster spec.
+	PollingInterval time.Duration
+
+	// DiscoveryRefreshInterval is the interval of refreshing the discovery of the clusters,
+	// the discovery is also refreshed when the CRDs or the versions of the clusters are changed.
+	DiscoveryRefreshInterval time.Duration
+
+	// HealthCheckFailureThreshold is the number of the consecutive failed health checks
+	// before the Ready condition of the cluster is set to false.
+	HealthCheckFailureThreshold int
+
+	// HealthCheckStopThreshold is the number of the consecutive failed health checks
+	// before the resource synchros of the cluster are stopped.
+	HealthCheckStopThreshold int
+
+	// ClockSkewThreshold is the max clock skew between the cluster and the manager,
+	// the Ready condition is reported with the ClockSkew reason if it is exceeded, 0 means no check.
+	ClockSkewThreshold time.Duration
+
+	// StatisticsPublishInterval is the interval of publishing the statistics of the resource synchros in the cluster status,
+	// the statistics are also published when the sync status of the resource is changed,
+	// and the real-time statistics are exposed by the metrics.
+	StatisticsPublishInterval time.Duration
+}
+
+func NewOptions() Options {
+	return Options{
+		BatchSize:          DefaultBatchSize,
+		BatchFlushInterval: DefaultBatchFlushInterval,
+		ClientQPS:          DefaultClientQPS,
+		ClientBurst:        DefaultClientBurst,
+		PollingInterval:    DefaultPollingInterval,
+
+		DiscoveryRefreshInterval: DefaultDiscoveryRefreshInterval,
+
+		HealthCheckFailureThreshold: DefaultHealthCheckFailureThreshold,
+		HealthCheckStopThreshold:    DefaultHealthCheckStopThreshold,
+		ClockSkewThreshold:          DefaultClockSkewThreshold,
+
+		StatisticsPublishInterval: DefaultStatisticsPublishInterval,
+	}
+}
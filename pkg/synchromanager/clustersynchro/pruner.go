@@ -0,0 +1,94 @@
+package clustersynchro
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	clustersv1alpha1 "github.com/clusterpedia-io/clusterpedia/pkg/apis/clusters/v1alpha1"
+	pedia "github.com/clusterpedia-io/clusterpedia/pkg/apis/pedia"
+)
+
+const lastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// resourcePruner prunes the fields of the resource before it is stored,
+// the pruned fields are recorded in the `shadow.clusterpedia.io/pruned-fields` annotation.
+type resourcePruner struct {
+	managedFields bool
+	annotations   []string
+	paths         [][]string
+}
+
+// newResourcePruner returns nil if there is nothing to prune
+func newResourcePruner(config *clustersv1alpha1.PruneConfig) *resourcePruner {
+	if config == nil {
+		return nil
+	}
+
+	annotations := sets.NewString(config.Annotations...)
+	if config.LastAppliedConfiguration {
+		annotations.Insert(lastAppliedConfigAnnotation)
+	}
+	annotations.Delete("", pedia.ShadowAnnotationPrunedFields, pedia.ShadowAnnotationMetadataOnly)
+
+	var paths [][]string
+	for _, path := range config.Paths {
+		path = strings.Trim(path, ".")
+		if path == "" {
+			continue
+		}
+		paths = append(paths, strings.Split(path, "."))
+	}
+
+	if !config.ManagedFields && annotations.Len() == 0 && len(paths) == 0 {
+		return nil
+	}
+	return &resourcePruner{
+		managedFields: config.ManagedFields,
+		annotations:   annotations.List(),
+		paths:         paths,
+	}
+}
+
+func (pruner *resourcePruner) Prune(obj *unstructured.Unstructured) {
+	if pruner == nil {
+		return
+	}
+
+	pruned := sets.NewString()
+	if pruner.managedFields && len(obj.GetManagedFields()) != 0 {
+		obj.SetManagedFields(nil)
+		pruned.Insert("metadata.managedFields")
+	}
+
+	for _, path := range pruner.paths {
+		if _, found, _ := unstructured.NestedFieldNoCopy(obj.Object, path...); found {
+			unstructured.RemoveNestedField(obj.Object, path...)
+			pruned.Insert(strings.Join(path, "."))
+		}
+	}
+
+	annotations := obj.GetAnnotations()
+	for _, key := range pruner.annotations {
+		if _, ok := annotations[key]; ok {
+			delete(annotations, key)
+			pruned.Insert("metadata.annotations[" + key + "]")
+		}
+	}
+
+	if pruned.Len() == 0 {
+		return
+	}
+
+	// the resource may be pruned repeatedly, merge the recorded fields
+	if fields := annotations[pedia.ShadowAnnotationPrunedFields]; fields != "" {
+		pruned.Insert(strings.Split(fields, ",")...)
+	}
+	if annotations == nil {
+		annotations = make(map[string]string, 1)
+	}
+
+	annotations[pedia.ShadowAnnotationPrunedFields] = strings.Join(pruned.List(), ",")
+	obj.SetAnnotations(annotations)
+}
@@ -32,6 +32,16 @@ type pressurequeue struct {
 	queue      []string
 	keyFunc    KeyFunc
 	closed     bool
+
+	pressedHandler func()
+}
+
+// SetPressedHandler sets the handler called when an event is pressed with the pending event of the same key
+func (q *pressurequeue) SetPressedHandler(handler func()) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	q.pressedHandler = handler
 }
 
 func (q *pressurequeue) Add(obj interface{}) error {
@@ -60,7 +70,11 @@ func (q *pressurequeue) queueActionLocked(action ActionType, obj interface{}) er
 	if err != nil {
 		return err
 	}
-	q.put(key, pressureEvents(q.items[key], &Event{Action: action, Object: obj}))
+	older, pressed := q.items[key]
+	if pressed && q.pressedHandler != nil {
+		q.pressedHandler()
+	}
+	q.put(key, pressureEvents(older, &Event{Action: action, Object: obj}))
 	return nil
 }
 
@@ -79,8 +93,13 @@ func (q *pressurequeue) Reput(event *Event) error {
 
 	q.processing.Delete(key)
 
+	// the newer event of the processing key is in the items but not in the queue,
+	// remove it from the items so that the merged event can be queued.
+	newer := q.items[key]
+	delete(q.items, key)
+
 	event.reputCount++
-	q.put(key, pressureEvents(event, q.items[key]))
+	q.put(key, pressureEvents(event, newer))
 	return nil
 }
 
@@ -142,26 +161,77 @@ func (q *pressurequeue) Pop() (*Event, error) {
 	}
 }
 
+// PopBatch waits until the queue is not empty and pops at most max events
+func (q *pressurequeue) PopBatch(max int) ([]*Event, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	for {
+		for len(q.queue) == 0 {
+			if q.closed {
+				return nil, ErrQueueClosed
+			}
+			q.cond.Wait()
+		}
+
+		if events := q.popLocked(max); len(events) != 0 {
+			return events, nil
+		}
+	}
+}
+
+// TryPopBatch pops at most max events without waiting
+func (q *pressurequeue) TryPopBatch(max int) ([]*Event, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if len(q.queue) == 0 && q.closed {
+		return nil, ErrQueueClosed
+	}
+	return q.popLocked(max), nil
+}
+
 func (q *pressurequeue) PopAll() ([]*Event, error) {
 	q.lock.Lock()
 	defer q.lock.Unlock()
-	if len(q.queue) == 0 {
-		if q.closed {
-			return nil, ErrQueueClosed
+	if len(q.queue) == 0 && q.closed {
+		return nil, ErrQueueClosed
+	}
+	return q.popLocked(len(q.queue)), nil
+}
+
+func (q *pressurequeue) popLocked(max int) []*Event {
+	if max < 1 {
+		max = 1
+	}
+
+	events := make([]*Event, 0, max)
+	for len(q.queue) != 0 && len(events) < max {
+		key := q.queue[0]
+		q.queue = q.queue[1:]
+
+		event, ok := q.items[key]
+		delete(q.items, key)
+		if !ok || event == nil {
+			continue
 		}
-		return []*Event{}, nil
+
+		q.processing.Insert(key)
+		events = append(events, event)
 	}
+	return events
+}
+
+func (q *pressurequeue) Len() int {
+	q.lock.RLock()
+	defer q.lock.RUnlock()
 
-	events := make([]*Event, 0, len(q.queue))
-	for _, key := range q.queue {
-		if event := q.items[key]; event != nil {
-			events = append(events, event)
-			q.processing.Insert(key)
+	keys := q.processing.Len()
+	for key := range q.items {
+		if !q.processing.Has(key) {
+			keys++
 		}
 	}
-	q.items = make(map[string]*Event)
-	q.queue = q.queue[:0]
-	return events, nil
+	return keys
 }
 
 func (q *pressurequeue) Close() {
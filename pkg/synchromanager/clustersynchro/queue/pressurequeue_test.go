@@ -0,0 +1,279 @@
+package queue
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+type testObject struct {
+	key     string
+	version int
+}
+
+func testKeyFunc(obj interface{}) (string, error) {
+	return obj.(testObject).key, nil
+}
+
+type testOp struct {
+	action  ActionType
+	key     string
+	version int
+}
+
+func (op testOp) apply(q *pressurequeue) error {
+	obj := testObject{key: op.key, version: op.version}
+	switch op.action {
+	case Added:
+		return q.Add(obj)
+	case Updated:
+		return q.Update(obj)
+	case Deleted:
+		return q.Delete(obj)
+	}
+	return fmt.Errorf("unknown action %q", op.action)
+}
+
+func eventString(event *Event) string {
+	obj := event.Object.(testObject)
+	return fmt.Sprintf("%s/%s/%d", obj.key, event.Action, obj.version)
+}
+
+func eventStrings(events []*Event) []string {
+	strs := make([]string, 0, len(events))
+	for _, event := range events {
+		strs = append(strs, eventString(event))
+	}
+	return strs
+}
+
+func TestPressureQueuePopBatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		ops     []testOp
+		max     int
+		want    []string
+		wantLen int
+	}{
+		{
+			name:    "max is larger than the queue",
+			ops:     []testOp{{Added, "a", 1}, {Added, "b", 1}},
+			max:     10,
+			want:    []string{"a/Added/1", "b/Added/1"},
+			wantLen: 2,
+		},
+		{
+			name:    "max is smaller than the queue",
+			ops:     []testOp{{Added, "a", 1}, {Added, "b", 1}, {Added, "c", 1}},
+			max:     2,
+			want:    []string{"a/Added/1", "b/Added/1"},
+			wantLen: 3,
+		},
+		{
+			name:    "max less than 1 pops one event",
+			ops:     []testOp{{Added, "a", 1}, {Added, "b", 1}},
+			max:     0,
+			want:    []string{"a/Added/1"},
+			wantLen: 2,
+		},
+		{
+			name:    "pressed events of a key are popped once",
+			ops:     []testOp{{Added, "a", 1}, {Updated, "a", 2}, {Added, "b", 1}, {Updated, "a", 3}},
+			max:     2,
+			want:    []string{"a/Added/3", "b/Added/1"},
+			wantLen: 2,
+		},
+		{
+			name:    "deleted event overwrites the pending event",
+			ops:     []testOp{{Updated, "a", 1}, {Deleted, "a", 2}},
+			max:     2,
+			want:    []string{"a/Deleted/2"},
+			wantLen: 1,
+		},
+		{
+			name:    "added event after deleted event is an update",
+			ops:     []testOp{{Deleted, "a", 1}, {Added, "a", 2}},
+			max:     2,
+			want:    []string{"a/Updated/2"},
+			wantLen: 1,
+		},
+	}
+
+	pops := map[string]func(q *pressurequeue, max int) ([]*Event, error){
+		"PopBatch":    (*pressurequeue).PopBatch,
+		"TryPopBatch": (*pressurequeue).TryPopBatch,
+	}
+	for popName, pop := range pops {
+		for _, test := range tests {
+			t.Run(popName+"/"+test.name, func(t *testing.T) {
+				q := NewPressureQueue(testKeyFunc)
+				for _, op := range test.ops {
+					if err := op.apply(q); err != nil {
+						t.Fatal(err)
+					}
+				}
+
+				events, err := pop(q, test.max)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got := eventStrings(events); !reflect.DeepEqual(got, test.want) {
+					t.Errorf("popped events = %v, want %v", got, test.want)
+				}
+				// the popped keys are processing until they are done
+				if got := q.Len(); got != test.wantLen {
+					t.Errorf("Len() = %d, want %d", got, test.wantLen)
+				}
+			})
+		}
+	}
+}
+
+func TestPressureQueueProcessingKey(t *testing.T) {
+	q := NewPressureQueue(testKeyFunc)
+	if err := q.Add(testObject{key: "a", version: 1}); err != nil {
+		t.Fatal(err)
+	}
+	events, err := q.TryPopBatch(10)
+	if err != nil || len(events) != 1 {
+		t.Fatalf("TryPopBatch() = %v, %v, want one event", eventStrings(events), err)
+	}
+
+	// the newer event of the processing key is not popped until the key is done
+	if err := q.Update(testObject{key: "a", version: 2}); err != nil {
+		t.Fatal(err)
+	}
+	if more, _ := q.TryPopBatch(10); len(more) != 0 {
+		t.Fatalf("TryPopBatch() = %v while the key is processing, want none", eventStrings(more))
+	}
+	if got := q.Len(); got != 1 {
+		t.Errorf("Len() = %d, want 1", got)
+	}
+
+	if err := q.Done(events[0]); err != nil {
+		t.Fatal(err)
+	}
+	more, err := q.TryPopBatch(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := eventStrings(more), []string{"a/Updated/2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("TryPopBatch() after done = %v, want %v", got, want)
+	}
+}
+
+func TestPressureQueueReput(t *testing.T) {
+	tests := []struct {
+		name           string
+		event          testOp
+		newer          *testOp
+		want           string
+		wantReputCount int
+	}{
+		{
+			name:           "without newer event",
+			event:          testOp{Added, "a", 1},
+			want:           "a/Added/1",
+			wantReputCount: 1,
+		},
+		{
+			name:           "added event with newer updated event",
+			event:          testOp{Added, "a", 1},
+			newer:          &testOp{Updated, "a", 2},
+			want:           "a/Added/2",
+			wantReputCount: 0,
+		},
+		{
+			name:           "updated event with newer updated event",
+			event:          testOp{Updated, "a", 1},
+			newer:          &testOp{Updated, "a", 2},
+			want:           "a/Updated/2",
+			wantReputCount: 0,
+		},
+		{
+			name:           "updated event with newer deleted event",
+			event:          testOp{Updated, "a", 1},
+			newer:          &testOp{Deleted, "a", 2},
+			want:           "a/Deleted/2",
+			wantReputCount: 0,
+		},
+		{
+			name:           "deleted event with newer added event",
+			event:          testOp{Deleted, "a", 1},
+			newer:          &testOp{Added, "a", 2},
+			want:           "a/Updated/2",
+			wantReputCount: 0,
+		},
+		{
+			name:           "deleted event with newer updated event",
+			event:          testOp{Deleted, "a", 1},
+			newer:          &testOp{Updated, "a", 2},
+			want:           "a/Deleted/1",
+			wantReputCount: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			q := NewPressureQueue(testKeyFunc)
+			if err := test.event.apply(q); err != nil {
+				t.Fatal(err)
+			}
+			events, err := q.TryPopBatch(1)
+			if err != nil || len(events) != 1 {
+				t.Fatalf("TryPopBatch() = %v, %v, want one event", eventStrings(events), err)
+			}
+
+			if test.newer != nil {
+				if err := test.newer.apply(q); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := q.Reput(events[0]); err != nil {
+				t.Fatal(err)
+			}
+
+			// the reput event is merged with the newer event into one queued event
+			if got := q.Len(); got != 1 {
+				t.Errorf("Len() = %d, want 1", got)
+			}
+			events, err = q.TryPopBatch(10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := eventStrings(events); !reflect.DeepEqual(got, []string{test.want}) {
+				t.Fatalf("TryPopBatch() after reput = %v, want [%s]", got, test.want)
+			}
+			if got := events[0].GetReputCount(); got != test.wantReputCount {
+				t.Errorf("GetReputCount() = %d, want %d", got, test.wantReputCount)
+			}
+
+			if err := q.Done(events[0]); err != nil {
+				t.Fatal(err)
+			}
+			if got := q.Len(); got != 0 {
+				t.Errorf("Len() after done = %d, want 0", got)
+			}
+		})
+	}
+}
+
+func TestPressureQueueClose(t *testing.T) {
+	q := NewPressureQueue(testKeyFunc)
+	if err := q.Add(testObject{key: "a", version: 1}); err != nil {
+		t.Fatal(err)
+	}
+	q.Close()
+
+	// the queued events can still be popped after the queue is closed
+	events, err := q.PopBatch(10)
+	if err != nil || len(events) != 1 {
+		t.Fatalf("PopBatch() = %v, %v, want one event", eventStrings(events), err)
+	}
+	if _, err := q.PopBatch(10); err != ErrQueueClosed {
+		t.Errorf("PopBatch() error = %v, want %v", err, ErrQueueClosed)
+	}
+	if _, err := q.TryPopBatch(10); err != ErrQueueClosed {
+		t.Errorf("TryPopBatch() error = %v, want %v", err, ErrQueueClosed)
+	}
+}
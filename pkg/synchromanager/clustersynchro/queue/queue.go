@@ -10,7 +10,13 @@ type EventQueue interface {
 	Delete(obj interface{}) error
 
 	Pop() (*Event, error)
+	PopBatch(max int) ([]*Event, error)
+	TryPopBatch(max int) ([]*Event, error)
 	Done(event *Event) error
+	Reput(event *Event) error
+
+	// Len returns the number of the keys that are pending or processing
+	Len() int
 
 	Close()
 }
@@ -0,0 +1,145 @@
+package clustersynchro
+
+import (
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/discovery"
+
+	clustersv1alpha1 "github.com/clusterpedia-io/clusterpedia/pkg/apis/clusters/v1alpha1"
+)
+
+func hasWildcardResources(clusterResources []clustersv1alpha1.ClusterResource) bool {
+	for _, resources := range clusterResources {
+		if isWildcardResources(resources) {
+			return true
+		}
+	}
+	return false
+}
+
+func isWildcardResources(resources clustersv1alpha1.ClusterResource) bool {
+	if resources.Group == clustersv1alpha1.ResourceWildcard {
+		return true
+	}
+	for _, resource := range resources.Resources {
+		if resource == clustersv1alpha1.ResourceWildcard {
+			return true
+		}
+	}
+	return false
+}
+
+func isExcludedResource(excludes []string, gr schema.GroupResource) bool {
+	for _, exclude := range excludes {
+		if exclude == gr.Resource || exclude == gr.String() {
+			return true
+		}
+	}
+	return false
+}
+
+// discoverGroupResources returns the listable resources of the groups with the preferred versions,
+// the partial resources are returned with the error if some groups are failed to be discovered.
+func (s *ClusterSynchro) discoverGroupResources() (map[string][]string, error) {
+	lists, err := discovery.ServerPreferredResources(s.discovery)
+
+	groupResources := make(map[string][]string)
+	for _, list := range lists {
+		gv, perr := schema.ParseGroupVersion(list.GroupVersion)
+		if perr != nil {
+			continue
+		}
+
+		for _, resource := range list.APIResources {
+			// skip the subresources
+			if strings.Contains(resource.Name, "/") {
+				continue
+			}
+			if !sets.NewString(resource.Verbs...).Has("list") {
+				continue
+			}
+			groupResources[gv.Group] = append(groupResources[gv.Group], resource.Name)
+		}
+	}
+	return groupResources, err
+}
+
+// expandClusterResources expands the wildcard groups and resources with the discovery of the cluster,
+// and removes the excluded resources.
+func (s *ClusterSynchro) expandClusterResources(clusterResources []clustersv1alpha1.ClusterResource) ([]clustersv1alpha1.ClusterResource, error) {
+	var (
+		discovered    map[string][]string
+		discoveredErr error
+	)
+
+	expanded := make([]clustersv1alpha1.ClusterResource, 0, len(clusterResources))
+	for _, resources := range clusterResources {
+		if !isWildcardResources(resources) {
+			if len(resources.ExcludeResources) == 0 {
+				expanded = append(expanded, resources)
+				continue
+			}
+
+			var names []string
+			for _, name := range resources.Resources {
+				if !isExcludedResource(resources.ExcludeResources, schema.GroupResource{Group: resources.Group, Resource: name}) {
+					names = append(names, name)
+				}
+			}
+			if len(names) != 0 {
+				resources := *resources.DeepCopy()
+				resources.Resources = names
+				resources.ExcludeResources = nil
+				expanded = append(expanded, resources)
+			}
+			continue
+		}
+
+		if discovered == nil {
+			discovered, discoveredErr = s.discoverGroupResources()
+		}
+
+		groups := []string{resources.Group}
+		if resources.Group == clustersv1alpha1.ResourceWildcard {
+			groups = make([]string, 0, len(discovered))
+			for group := range discovered {
+				groups = append(groups, group)
+			}
+			sort.Strings(groups)
+		}
+
+		allResources := sets.NewString(resources.Resources...).Has(clustersv1alpha1.ResourceWildcard)
+		for _, group := range groups {
+			candidates := resources.Resources
+			if allResources {
+				candidates = discovered[group]
+			} else if resources.Group == clustersv1alpha1.ResourceWildcard {
+				// only the groups that have the resources are selected
+				candidates = sets.NewString(discovered[group]...).Intersection(sets.NewString(resources.Resources...)).List()
+			}
+
+			var names []string
+			for _, name := range candidates {
+				if !isExcludedResource(resources.ExcludeResources, schema.GroupResource{Group: group, Resource: name}) {
+					names = append(names, name)
+				}
+			}
+			if len(names) == 0 {
+				continue
+			}
+
+			expandedResources := *resources.DeepCopy()
+			expandedResources.Group = group
+			expandedResources.Resources = names
+			expandedResources.ExcludeResources = nil
+			if resources.Group == clustersv1alpha1.ResourceWildcard {
+				expandedResources.Versions = nil
+			}
+			expanded = append(expanded, expandedResources)
+		}
+	}
+	return expanded, discoveredErr
+}
@@ -2,6 +2,9 @@ package clustersynchro
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -20,24 +23,74 @@ import (
 	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
 	"github.com/clusterpedia-io/clusterpedia/pkg/synchromanager/clustersynchro/informer"
 	"github.com/clusterpedia-io/clusterpedia/pkg/synchromanager/clustersynchro/queue"
+	"github.com/clusterpedia-io/clusterpedia/pkg/synchromanager/metrics"
 	"github.com/clusterpedia-io/clusterpedia/pkg/utils"
 )
 
+const (
+	// maxStorageRetries is the max number of retries for the failed storage writes
+	maxStorageRetries = 5
+
+	storageRetryBaseDelay = time.Second
+	storageRetryMaxDelay  = 30 * time.Second
+
+	// maxReportedFailedResources limits the number of failed resource keys in the sync condition
+	maxReportedFailedResources = 10
+
+	// defaultStreamingListPageSize is the page size of the streaming and polling lists if the page size is not set
+	defaultStreamingListPageSize = 500
+
+	// batchUpsertAction is the action label of the batch upserts in the storage write metrics
+	batchUpsertAction = "BatchUpsert"
+)
+
+// errListCanceled is returned by the list of the stopped informer which is waiting for the list limiter
+var errListCanceled = errors.New("list request is canceled while waiting for the concurrent lists limit")
+
 type ResourceSynchro struct {
 	cluster         string
 	storageResource schema.GroupResource
 
 	queue         queue.EventQueue
 	listerWatcher cache.ListerWatcher
+	listLimiter   *listLimiter
+	listConfig    clustersv1alpha1.ListConfig
 	cache         *informer.ResourceVersionStorage
 
+	// pollingInterval relists the resources on the interval instead of watching them if it is not 0
+	pollingInterval time.Duration
+
 	memoryVersion schema.GroupVersion
 	convertor     runtime.ObjectConvertor
+	pruner        atomic.Value // *resourcePruner
 	storage       storage.ResourceStorage
 	status        atomic.Value // clustersv1alpha1.ClusterResourceSyncCondition
 
-	runlock sync.Mutex
-	stoped  chan struct{}
+	batchSize     int
+	flushInterval time.Duration
+
+	// metricLabels are the cluster, resource and version labels of the metrics
+	metricLabels []string
+
+	// metricsDeleted is set when the series of the synchro are deleted,
+	// so that the queue depth published by the cluster synchro is not exported again.
+	metricsLock    sync.Mutex
+	metricsDeleted bool
+
+	failureLock      sync.Mutex
+	failures         map[string]struct{}
+	lastStorageError string
+
+	hasSynced         atomic.Value // func() bool
+	initialListSynced int32
+	processedEvents   int64
+	lastEventTime     atomic.Value // time.Time
+	lastWriteTime     atomic.Value // time.Time
+
+	runlock         sync.Mutex
+	stoped          chan struct{}
+	informerStopper chan struct{}
+	pauser          chan struct{}
 
 	closeOnce sync.Once
 	ctx       context.Context
@@ -46,29 +99,49 @@ type ResourceSynchro struct {
 	closed    chan struct{}
 }
 
-func newResourceSynchro(cluster string, lw cache.ListerWatcher, rvcache *informer.ResourceVersionStorage,
-	convertor runtime.ObjectConvertor, storage storage.ResourceStorage,
+func newResourceSynchro(cluster string, lw cache.ListerWatcher, listLimiter *listLimiter, listConfig clustersv1alpha1.ListConfig, pollingInterval time.Duration, rvcache *informer.ResourceVersionStorage,
+	convertor runtime.ObjectConvertor, storage storage.ResourceStorage, options Options,
 ) *ResourceSynchro {
 	ctx, cancel := context.WithCancel(context.Background())
+	storageConfig := storage.GetStorageConfig()
+	metricLabels := []string{cluster, storageConfig.StorageGroupResource.String(), storageConfig.StorageVersion.Version}
+	eventQueue := queue.NewPressureQueue(cache.DeletionHandlingMetaNamespaceKeyFunc)
+	eventQueue.SetPressedHandler(metrics.ResourceEventsCompressed.WithLabelValues(metricLabels...).Inc)
+
 	synchro := &ResourceSynchro{
 		cluster:         cluster,
-		storageResource: storage.GetStorageConfig().StorageGroupResource,
+		storageResource: storageConfig.StorageGroupResource,
 
-		listerWatcher: lw,
-		cache:         rvcache,
-		queue:         queue.NewPressureQueue(cache.DeletionHandlingMetaNamespaceKeyFunc),
+		listerWatcher:   lw,
+		listLimiter:     listLimiter,
+		listConfig:      listConfig,
+		pollingInterval: pollingInterval,
+		cache:           rvcache,
+		queue:           eventQueue,
 
 		storage:       storage,
 		convertor:     convertor,
-		memoryVersion: storage.GetStorageConfig().MemoryVersion,
+		memoryVersion: storageConfig.MemoryVersion,
 
-		ctx:    ctx,
-		cancel: cancel,
-		stoped: make(chan struct{}),
-		closer: make(chan struct{}),
-		closed: make(chan struct{}),
+		batchSize:     options.BatchSize,
+		flushInterval: options.BatchFlushInterval,
+		metricLabels:  metricLabels,
+
+		failures: make(map[string]struct{}),
+
+		ctx:             ctx,
+		cancel:          cancel,
+		stoped:          make(chan struct{}),
+		informerStopper: make(chan struct{}),
+		pauser:          make(chan struct{}),
+		closer:          make(chan struct{}),
+		closed:          make(chan struct{}),
 	}
 	close(synchro.stoped)
+	synchro.pruner.Store((*resourcePruner)(nil))
+	synchro.hasSynced.Store(func() bool { return false })
+	synchro.lastEventTime.Store(time.Time{})
+	synchro.lastWriteTime.Store(time.Time{})
 
 	status := clustersv1alpha1.ClusterResourceSyncCondition{
 		Status:             clustersv1alpha1.SyncStatusPending,
@@ -81,12 +154,20 @@ func newResourceSynchro(cluster string, lw cache.ListerWatcher, rvcache *informe
 }
 
 func (synchro *ResourceSynchro) Run(stopCh <-chan struct{}) {
+	synchro.runlock.Lock()
+	pauser := synchro.pauser
+	synchro.runlock.Unlock()
+
 	for {
 		select {
 		case <-stopCh:
 			return
 		case <-synchro.closer:
 			return
+		case <-synchro.informerStopper:
+			return
+		case <-pauser:
+			return
 		case <-synchro.stoped:
 		}
 
@@ -100,6 +181,10 @@ func (synchro *ResourceSynchro) Run(stopCh <-chan struct{}) {
 				return
 			case <-synchro.closer:
 				return
+			case <-synchro.informerStopper:
+				return
+			case <-pauser:
+				return
 			default:
 			}
 
@@ -118,13 +203,19 @@ func (synchro *ResourceSynchro) Run(stopCh <-chan struct{}) {
 
 	defer close(synchro.stoped)
 
+	// the slot of the list limiter is held from the first page to the last page of a relist,
+	// and it is released when the informer is stopped in the middle of the relist.
+	listSlot := newRelistSlot(synchro.listLimiter)
 	informerStopCh := make(chan struct{})
 	go func() {
 		select {
 		case <-stopCh:
 		case <-synchro.closer:
+		case <-synchro.informerStopper:
+		case <-pauser:
 		}
 		close(informerStopCh)
+		listSlot.Stop()
 	}()
 
 	status := clustersv1alpha1.ClusterResourceSyncCondition{
@@ -133,13 +224,83 @@ func (synchro *ResourceSynchro) Run(stopCh <-chan struct{}) {
 	}
 	synchro.status.Store(status)
 
-	informer.NewResourceVersionInformer(
-		synchro.cluster,
-		synchro.listerWatcher,
-		synchro.cache,
-		&unstructured.Unstructured{},
-		synchro,
-	).Run(informerStopCh)
+	// count the lists of the informer, a relist means the watch is broken or expired
+	relists := metrics.InformerRelists.WithLabelValues(synchro.metricLabels...)
+	lw := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			// the waiting for the list limiter is canceled when the informer is stopped
+			if !listSlot.Acquire(informerStopCh) {
+				return nil, errListCanceled
+			}
+
+			if options.Continue == "" {
+				relists.Inc()
+			}
+			list, err := synchro.listerWatcher.List(options)
+			if err != nil {
+				// the relist is restarted from the first page after the failure
+				listSlot.Release()
+				return nil, err
+			}
+			if listMeta, err := meta.ListAccessor(list); err != nil || listMeta.GetContinue() == "" {
+				// the last page of the relist
+				listSlot.Release()
+			}
+			return list, nil
+		},
+		WatchFunc: synchro.listerWatcher.Watch,
+	}
+
+	pageSize := synchro.listConfig.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultStreamingListPageSize
+	}
+
+	// the next page is listed after the queued resources are fewer than a page,
+	// so that about two pages of resources are held in memory at most.
+	waitForCapacity := func(stopCh <-chan struct{}) {
+		_ = wait.PollImmediateUntil(100*time.Millisecond, func() (bool, error) {
+			return int64(synchro.queue.Len()) < pageSize, nil
+		}, stopCh)
+	}
+
+	var rvinformer informer.ResourceVersionInformer
+	switch {
+	case synchro.pollingInterval > 0:
+		klog.InfoS("resource does not support watch, poll resources", "cluster", synchro.cluster,
+			"resource", synchro.storageResource, "interval", synchro.pollingInterval)
+		rvinformer = informer.NewPollingResourceVersionInformer(
+			synchro.cluster,
+			lw,
+			synchro.cache,
+			pageSize,
+			synchro.pollingInterval,
+			synchro,
+			waitForCapacity,
+		)
+	case synchro.listConfig.Streaming:
+		rvinformer = informer.NewStreamingResourceVersionInformer(
+			synchro.cluster,
+			lw,
+			synchro.cache,
+			pageSize,
+			synchro,
+			waitForCapacity,
+		)
+	default:
+		rvinformer = informer.NewResourceVersionInformer(
+			synchro.cluster,
+			lw,
+			synchro.cache,
+			&unstructured.Unstructured{},
+			synchro,
+			synchro.listConfig.PageSize,
+		)
+	}
+	atomic.StoreInt32(&synchro.initialListSynced, 0)
+	synchro.hasSynced.Store(rvinformer.HasSynced)
+
+	rvinformer.Run(informerStopCh)
 
 	status = clustersv1alpha1.ClusterResourceSyncCondition{
 		Status:             clustersv1alpha1.SyncStatusStop,
@@ -156,26 +317,160 @@ func (synchro *ResourceSynchro) Close() {
 	})
 
 	<-synchro.closed
+	// the events are not recorded after the informer is stopped
+	synchro.waitInformerStopped()
+	synchro.deleteMetrics()
 	klog.InfoS("resource synchro  is closed", "cluster", synchro.cluster, "resource", synchro.storageResource)
 	//klog.V(2).InfoS("resource synchro  is closed", "cluster", synchro.cluster, "resource", synchro.storageResource)
 }
 
-func (synchro *ResourceSynchro) OnAdd(obj interface{}) {
-	// robj := obj.(runtime.Object).DeepCopyObject()
+// Shutdown stops the informer first, then writes the pending resources in the queue to the storage
+// until the ctx is done, and closes the synchro at last.
+// The resources that are not written in time will be resynchronized by the next synchro.
+func (synchro *ResourceSynchro) Shutdown(ctx context.Context) {
+	synchro.runlock.Lock()
+	select {
+	case <-synchro.informerStopper:
+	default:
+		close(synchro.informerStopper)
+	}
+	synchro.runlock.Unlock()
+	synchro.waitInformerStopped()
+
+	// no more events are added to the queue, the storager exits after the queue is drained
+	synchro.queue.Close()
+	err := wait.PollImmediateUntil(100*time.Millisecond, func() (bool, error) {
+		return synchro.queue.Len() == 0, nil
+	}, ctx.Done())
+	if err != nil {
+		klog.InfoS("resource synchro is closed before the queue is drained", "cluster", synchro.cluster,
+			"resource", synchro.storageResource, "pending", synchro.queue.Len())
+	}
+
+	synchro.Close()
+
+	status := clustersv1alpha1.ClusterResourceSyncCondition{
+		Status:             clustersv1alpha1.SyncStatusStop,
+		Reason:             "SynchroShutdown",
+		LastTransitionTime: metav1.Now(),
+	}
+	if pending := synchro.queue.Len(); pending != 0 {
+		status.Message = fmt.Sprintf("%d resources are not stored before shutdown", pending)
+	}
+	synchro.status.Store(status)
+}
+
+// Pause stops the informer of the synchro,
+// the stored resources and the resource version cache are kept for resuming.
+func (synchro *ResourceSynchro) Pause() {
+	synchro.runlock.Lock()
+	select {
+	case <-synchro.pauser:
+		synchro.runlock.Unlock()
+		return
+	default:
+		close(synchro.pauser)
+	}
+	synchro.runlock.Unlock()
+
+	synchro.waitInformerStopped()
+	status := clustersv1alpha1.ClusterResourceSyncCondition{
+		Status:             clustersv1alpha1.SyncStatusPaused,
+		Reason:             "SynchroPaused",
+		LastTransitionTime: metav1.Now(),
+	}
+	synchro.status.Store(status)
+	klog.InfoS("resource synchro is paused", "cluster", synchro.cluster, "resource", synchro.storageResource)
+}
+
+// Resume returns true if the synchro is paused before, and the synchro needs to be run again,
+// the resources are synchronized incrementally with the resource version cache.
+func (synchro *ResourceSynchro) Resume() bool {
+	synchro.runlock.Lock()
+	defer synchro.runlock.Unlock()
+
+	select {
+	case <-synchro.pauser:
+		synchro.pauser = make(chan struct{})
+	default:
+		return false
+	}
+
+	status := clustersv1alpha1.ClusterResourceSyncCondition{
+		Status:             clustersv1alpha1.SyncStatusPending,
+		LastTransitionTime: metav1.Now(),
+	}
+	synchro.status.Store(status)
+	klog.InfoS("resource synchro is resumed", "cluster", synchro.cluster, "resource", synchro.storageResource)
+	return true
+}
+
+// waitInformerStopped waits for the informer to stop after the synchro is closed
+func (synchro *ResourceSynchro) waitInformerStopped() {
+	synchro.runlock.Lock()
+	stoped := synchro.stoped
+	synchro.runlock.Unlock()
+
+	<-stoped
+}
+
+// storageWriteActions are the values of the action label of the storage write metrics
+var storageWriteActions = []string{batchUpsertAction, string(queue.Added), string(queue.Updated), string(queue.Deleted)}
+
+// deleteMetrics deletes all series of the synchro, it is called after the storager and the informer are stopped
+func (synchro *ResourceSynchro) deleteMetrics() {
+	synchro.metricsLock.Lock()
+	defer synchro.metricsLock.Unlock()
+	synchro.metricsDeleted = true
+
+	labels := synchro.metricLabels
+	metrics.ResourceQueueDepth.DeleteLabelValues(labels...)
+	metrics.ResourceEventsCompressed.DeleteLabelValues(labels...)
+	metrics.ResourceEventsProcessed.DeleteLabelValues(labels...)
+	metrics.InformerRelists.DeleteLabelValues(labels...)
+	for _, action := range []queue.ActionType{queue.Added, queue.Updated, queue.Deleted} {
+		metrics.ResourceEventsAdded.DeleteLabelValues(append(labels, string(action))...)
+	}
+	for _, action := range storageWriteActions {
+		metrics.StorageWriteDuration.DeleteLabelValues(append(labels, action)...)
+		metrics.StorageWriteErrors.DeleteLabelValues(append(labels, action)...)
+	}
+}
+
+// setQueueDepthMetric sets the queue depth gauge, it is only called when the cluster status is generated
+func (synchro *ResourceSynchro) setQueueDepthMetric(depth int) {
+	synchro.metricsLock.Lock()
+	defer synchro.metricsLock.Unlock()
+	if !synchro.metricsDeleted {
+		metrics.ResourceQueueDepth.WithLabelValues(synchro.metricLabels...).Set(float64(depth))
+	}
+}
+
+// SetPruner sets the pruner used for the resources that are not yet stored
+func (synchro *ResourceSynchro) SetPruner(pruner *resourcePruner) {
+	synchro.pruner.Store(pruner)
+}
 
-	// TODO(iceber): remove ManagedFields ?
+func (synchro *ResourceSynchro) OnAdd(obj interface{}) {
+	synchro.recordEvent(queue.Added)
 	synchro.queue.Add(obj)
 }
 
 func (synchro *ResourceSynchro) OnUpdate(_, obj interface{}) {
-	// TODO(iceber): remove ManagedFields ?
+	synchro.recordEvent(queue.Updated)
 	synchro.queue.Update(obj)
 }
 
 func (synchro *ResourceSynchro) OnDelete(obj interface{}) {
+	synchro.recordEvent(queue.Deleted)
 	synchro.queue.Delete(obj)
 }
 
+func (synchro *ResourceSynchro) recordEvent(action queue.ActionType) {
+	synchro.lastEventTime.Store(time.Now())
+	metrics.ResourceEventsAdded.WithLabelValues(append(synchro.metricLabels, string(action))...).Inc()
+}
+
 func (synchro *ResourceSynchro) OnSync(obj interface{}) {
 }
 
@@ -184,11 +479,11 @@ func (synchro *ResourceSynchro) storager(worker int) {
 	for i := 0; i < worker; i++ {
 		waitGroup.Add(1)
 
-		go wait.Until(func() {
+		go func() {
 			defer waitGroup.Done()
 
-			synchro.processResources()
-		}, time.Second, synchro.closer)
+			wait.Until(synchro.processResources, time.Second, synchro.closer)
+		}()
 	}
 
 	waitGroup.Wait()
@@ -203,7 +498,7 @@ func (synchro *ResourceSynchro) processResources() {
 		default:
 		}
 
-		event, err := synchro.queue.Pop()
+		events, err := synchro.queue.PopBatch(synchro.batchSize)
 		if err != nil {
 			if err == queue.ErrQueueClosed {
 				return
@@ -213,17 +508,76 @@ func (synchro *ResourceSynchro) processResources() {
 			continue
 		}
 
-		synchro.handleResourceEvent(event)
+		// wait for more events if the batch is not full
+		if len(events) < synchro.batchSize && synchro.flushInterval > 0 {
+			timer := time.NewTimer(synchro.flushInterval)
+			select {
+			case <-timer.C:
+			case <-synchro.closer:
+				timer.Stop()
+			}
+
+			if more, err := synchro.queue.TryPopBatch(synchro.batchSize - len(events)); err == nil {
+				events = append(events, more...)
+			}
+		}
+
+		synchro.handleResourceEvents(events)
+	}
+}
+
+// handleResourceEvents writes the added and updated resources with a batch upsert,
+// if the batch upsert fails, the resources are written one by one.
+func (synchro *ResourceSynchro) handleResourceEvents(events []*queue.Event) {
+	upsertEvents := make([]*queue.Event, 0, len(events))
+	objs := make([]runtime.Object, 0, len(events))
+	for _, event := range events {
+		if event.Action == queue.Deleted {
+			synchro.handleResourceEvent(event)
+			continue
+		}
+
+		obj, err := synchro.convertObject(event.Object.(runtime.Object))
+		if err != nil {
+			klog.Error(err)
+			synchro.giveUpEvent(event, err)
+			continue
+		}
+		upsertEvents = append(upsertEvents, event)
+		objs = append(objs, obj)
+	}
+
+	if len(objs) == 0 {
+		return
+	}
+
+	start := time.Now()
+	err := synchro.storage.BatchUpsert(synchro.ctx, synchro.cluster, objs)
+	synchro.observeStorageWrite(batchUpsertAction, start, err)
+	if err != nil {
+		klog.ErrorS(err, "Failed to batch upsert resources, fall back to handle them one by one",
+			"cluster", synchro.cluster,
+			"resource", synchro.storageResource,
+			"count", len(objs),
+		)
+
+		for i, event := range upsertEvents {
+			synchro.finishEvent(event, synchro.storeResource(event.Action, objs[i]))
+		}
+		return
+	}
+
+	for _, event := range upsertEvents {
+		synchro.finishEvent(event, nil)
 	}
 }
 
 func (synchro *ResourceSynchro) handleResourceEvent(event *queue.Event) {
-	defer synchro.queue.Done(event)
-
 	if d, ok := event.Object.(cache.DeletedFinalStateUnknown); ok {
 		namespace, name, err := cache.SplitMetaNamespaceKey(d.Key)
 		if err != nil {
 			klog.Error(err)
+			synchro.queue.Done(event)
 			return
 		}
 		obj := &metav1.PartialObjectMetadata{
@@ -233,22 +587,117 @@ func (synchro *ResourceSynchro) handleResourceEvent(event *queue.Event) {
 			},
 		}
 
-		synchro.deleteResource(obj)
+		synchro.finishEvent(event, synchro.storeResource(event.Action, obj))
 		return
 	}
 
-	var err error
-	obj := event.Object.(runtime.Object)
+	obj, err := synchro.convertObject(event.Object.(runtime.Object))
+	if err != nil {
+		klog.Error(err)
+		synchro.giveUpEvent(event, err)
+		return
+	}
+	synchro.finishEvent(event, synchro.storeResource(event.Action, obj))
+}
+
+// finishEvent marks the event as done if it is handled successfully,
+// otherwise the event is requeued with exponential backoff until the retries are exhausted.
+func (synchro *ResourceSynchro) finishEvent(event *queue.Event, err error) {
+	if err == nil {
+		if key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(event.Object); err == nil {
+			synchro.removeFailure(key)
+		}
+		atomic.AddInt64(&synchro.processedEvents, 1)
+		metrics.ResourceEventsProcessed.WithLabelValues(synchro.metricLabels...).Inc()
+		synchro.lastWriteTime.Store(time.Now())
+		synchro.queue.Done(event)
+		return
+	}
+
+	synchro.failureLock.Lock()
+	synchro.lastStorageError = err.Error()
+	synchro.failureLock.Unlock()
+
+	retries := event.GetReputCount()
+	if retries >= maxStorageRetries {
+		synchro.giveUpEvent(event, err)
+		return
+	}
+
+	delay := storageRetryBaseDelay << retries
+	if delay > storageRetryMaxDelay {
+		delay = storageRetryMaxDelay
+	}
+
+	// the key of the event is still processing until it is reput,
+	// the newer events of the key will be merged with it.
+	time.AfterFunc(delay, func() {
+		select {
+		case <-synchro.closer:
+			return
+		default:
+		}
+
+		if err := synchro.queue.Reput(event); err != nil {
+			klog.Error(err)
+		}
+	})
+}
+
+// giveUpEvent records the failed resource in the sync condition and marks the event as done
+func (synchro *ResourceSynchro) giveUpEvent(event *queue.Event, err error) {
+	defer synchro.queue.Done(event)
+	atomic.AddInt64(&synchro.processedEvents, 1)
+
+	key, kerr := cache.DeletionHandlingMetaNamespaceKeyFunc(event.Object)
+	if kerr != nil {
+		klog.Error(kerr)
+		return
+	}
+
+	klog.ErrorS(err, "Give up handling resource",
+		"cluster", synchro.cluster,
+		"action", event.Action,
+		"resource", synchro.storageResource,
+		"key", key,
+		"retries", event.GetReputCount(),
+	)
+
+	synchro.failureLock.Lock()
+	defer synchro.failureLock.Unlock()
+	synchro.failures[key] = struct{}{}
+	synchro.lastStorageError = err.Error()
+}
+
+func (synchro *ResourceSynchro) removeFailure(key string) {
+	synchro.failureLock.Lock()
+	defer synchro.failureLock.Unlock()
+	delete(synchro.failures, key)
+}
+
+func (synchro *ResourceSynchro) convertObject(obj runtime.Object) (runtime.Object, error) {
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		synchro.pruner.Load().(*resourcePruner).Prune(u)
+	}
+
 	if synchro.convertor != nil {
+		var err error
 		obj, err = synchro.convertor.ConvertToVersion(obj, synchro.memoryVersion)
 		if err != nil {
-			klog.Error(err)
-			return
+			return nil, err
 		}
 	}
 	utils.InjectClusterName(obj, synchro.cluster)
+	return obj, nil
+}
+
+func (synchro *ResourceSynchro) storeResource(action queue.ActionType, obj runtime.Object) error {
+	var err error
+	defer func(start time.Time) {
+		synchro.observeStorageWrite(string(action), start, err)
+	}(time.Now())
 
-	switch event.Action {
+	switch action {
 	case queue.Added:
 		err = synchro.createOrUpdateResource(obj)
 	case queue.Updated:
@@ -261,12 +710,21 @@ func (synchro *ResourceSynchro) handleResourceEvent(event *queue.Event) {
 		o, _ := meta.Accessor(obj)
 		klog.ErrorS(err, "Failed to handler resource",
 			"cluster", synchro.cluster,
-			"action", event.Action,
+			"action", action,
 			"resource", synchro.storageResource,
 			"namespace", o.GetNamespace(),
 			"name", o.GetName(),
 		)
 	}
+	return err
+}
+
+func (synchro *ResourceSynchro) observeStorageWrite(action string, start time.Time, err error) {
+	labels := append(synchro.metricLabels, action)
+	metrics.StorageWriteDuration.WithLabelValues(labels...).Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.StorageWriteErrors.WithLabelValues(labels...).Inc()
+	}
 }
 
 func (synchro *ResourceSynchro) createOrUpdateResource(obj runtime.Object) error {
@@ -290,5 +748,44 @@ func (synchro *ResourceSynchro) deleteResource(obj runtime.Object) error {
 }
 
 func (synchro *ResourceSynchro) Status() clustersv1alpha1.ClusterResourceSyncCondition {
-	return synchro.status.Load().(clustersv1alpha1.ClusterResourceSyncCondition)
+	status := synchro.status.Load().(clustersv1alpha1.ClusterResourceSyncCondition)
+	status.QueueDepth = synchro.queue.Len()
+	status.StoredResourceCount = synchro.cache.Len()
+	status.ProcessedEventCount = atomic.LoadInt64(&synchro.processedEvents)
+	if t := synchro.lastEventTime.Load().(time.Time); !t.IsZero() {
+		status.LastEventTime = &metav1.Time{Time: t}
+	}
+	if t := synchro.lastWriteTime.Load().(time.Time); !t.IsZero() {
+		status.LastWriteTime = &metav1.Time{Time: t}
+	}
+
+	// the initial list is synced after the informer has synced and the queued resources are stored
+	if atomic.LoadInt32(&synchro.initialListSynced) == 0 && synchro.hasSynced.Load().(func() bool)() && status.QueueDepth == 0 {
+		atomic.StoreInt32(&synchro.initialListSynced, 1)
+	}
+	status.InitialListSynced = atomic.LoadInt32(&synchro.initialListSynced) == 1
+
+	synchro.failureLock.Lock()
+	defer synchro.failureLock.Unlock()
+	status.LastStorageError = synchro.lastStorageError
+	if len(synchro.failures) == 0 {
+		return status
+	}
+
+	keys := make([]string, 0, len(synchro.failures))
+	for key := range synchro.failures {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	if len(keys) > maxReportedFailedResources {
+		keys = keys[:maxReportedFailedResources]
+	}
+
+	status.FailedResourceCount = len(synchro.failures)
+	status.FailedResources = keys
+	if status.Reason == "" {
+		status.Reason = "ResourceStorageFailed"
+		status.Message = fmt.Sprintf("%d resources failed to be stored", len(synchro.failures))
+	}
+	return status
 }
@@ -0,0 +1,98 @@
+package clustersynchro
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+
+	clustersv1alpha1 "github.com/clusterpedia-io/clusterpedia/pkg/apis/clusters/v1alpha1"
+	"github.com/clusterpedia-io/clusterpedia/pkg/synchromanager/clustersynchro/informer"
+)
+
+// resourceSelector limits the resources that are synchronized from the member cluster
+type resourceSelector struct {
+	namespaces    []string
+	labelSelector string
+	fieldSelector string
+
+	// metadataOnly lists and watches only the metadata of the resources
+	metadataOnly bool
+}
+
+func newResourceSelector(resource clustersv1alpha1.ClusterResource, namespaced bool) (resourceSelector, error) {
+	selector := resourceSelector{metadataOnly: resource.MetadataOnly}
+	if resource.LabelSelector != nil {
+		labelSelector, err := metav1.LabelSelectorAsSelector(resource.LabelSelector)
+		if err != nil {
+			return selector, fmt.Errorf("invalid label selector: %v", err)
+		}
+		selector.labelSelector = labelSelector.String()
+	}
+
+	var fieldSelectors []fields.Selector
+	if resource.FieldSelector != "" {
+		fieldSelector, err := fields.ParseSelector(resource.FieldSelector)
+		if err != nil {
+			return selector, fmt.Errorf("invalid field selector: %v", err)
+		}
+		fieldSelectors = append(fieldSelectors, fieldSelector)
+	}
+
+	if namespaced {
+		namespaces := sets.NewString(resource.Namespaces...)
+		excludeNamespaces := sets.NewString(resource.ExcludeNamespaces...)
+		if namespaces.Len() != 0 {
+			namespaces = namespaces.Difference(excludeNamespaces)
+			if namespaces.Len() == 0 {
+				return selector, fmt.Errorf("all namespaces are excluded")
+			}
+			selector.namespaces = namespaces.List()
+		} else {
+			for _, namespace := range excludeNamespaces.List() {
+				fieldSelectors = append(fieldSelectors, fields.OneTermNotEqualSelector("metadata.namespace", namespace))
+			}
+		}
+	}
+
+	if len(fieldSelectors) != 0 {
+		selector.fieldSelector = fields.AndSelectors(fieldSelectors...).String()
+	}
+	return selector, nil
+}
+
+func (selector resourceSelector) Equal(other resourceSelector) bool {
+	return selector.labelSelector == other.labelSelector && selector.fieldSelector == other.fieldSelector &&
+		selector.metadataOnly == other.metadataOnly &&
+		sets.NewString(selector.namespaces...).Equal(sets.NewString(other.namespaces...))
+}
+
+func (selector resourceSelector) tweakListOptions(options *metav1.ListOptions) {
+	options.LabelSelector = selector.labelSelector
+	options.FieldSelector = selector.fieldSelector
+}
+
+// newListerWatcher returns the ListerWatcher of the resource filtered by the selector,
+// a single namespace is listed and watched directly, multiple namespaces are filtered on the client side.
+func (s *ClusterSynchro) newListerWatcher(gvr schema.GroupVersionResource, kind string, selector resourceSelector) cache.ListerWatcher {
+	namespace := metav1.NamespaceAll
+	if len(selector.namespaces) == 1 {
+		namespace = selector.namespaces[0]
+	}
+
+	var lw cache.ListerWatcher
+	if selector.metadataOnly {
+		lw = s.metadataListerWatcherFactory.ForResourceWithOptions(namespace, gvr, selector.tweakListOptions)
+		lw = informer.NewMetadataOnlyListerWatcher(lw, gvr.GroupVersion().WithKind(kind))
+	} else {
+		lw = s.listerWatcherFactory.ForResourceWithOptions(namespace, gvr, selector.tweakListOptions)
+	}
+	if len(selector.namespaces) > 1 {
+		lw = informer.NewNamespacesFilteredListerWatcher(lw, selector.namespaces)
+	}
+
+	return lw
+}
@@ -5,17 +5,25 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
-	"reflect"
+	"net/http"
+	"net/url"
 	"sync"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/informers"
 	clientset "k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -26,48 +34,88 @@ import (
 	clusterlister "github.com/clusterpedia-io/clusterpedia/pkg/generated/listers/clusters/v1alpha1"
 	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
 	"github.com/clusterpedia-io/clusterpedia/pkg/synchromanager/clustersynchro"
+	"github.com/clusterpedia-io/clusterpedia/pkg/synchromanager/metrics"
+	"github.com/clusterpedia-io/clusterpedia/pkg/synchromanager/sharding"
 )
 
 const ClusterSynchroControllerFinalizer = "clusterpedia.io/cluster-synchro-controller"
 
+// the reasons of the failures before the cluster synchro is created,
+// the reasons returned by the clustersynchro.New are also reported.
+const (
+	ClusterReasonInvalidProxyURL       = "InvalidProxyURL"
+	ClusterReasonSynchroCreationFailed = "SynchroCreationFailed"
+	ClusterReasonSyncResourcesNotFound = "SyncResourcesNotFound"
+	ClusterReasonSyncResourcesInvalid  = "SyncResourcesInvalid"
+)
+
+var errInvalidProxyURL = errors.New("Cluster proxy URL is invalid")
+
 type Manager struct {
 	closeOnce sync.Once
 	closer    chan struct{}
 
-	kubeclient         clientset.Interface
-	clusterpediaclient crdclientset.Interface
-	informerFactory    externalversions.SharedInformerFactory
+	kubeclient          clientset.Interface
+	clusterpediaclient  crdclientset.Interface
+	informerFactory     externalversions.SharedInformerFactory
+	kubeInformerFactory informers.SharedInformerFactory
 
 	queue           workqueue.RateLimitingInterface
 	storage         storage.StorageFactory
 	clusterlister   clusterlister.PediaClusterLister
 	clusterInformer cache.SharedIndexInformer
+	secretlister    corelisters.SecretLister
+	secretInformer  cache.SharedIndexInformer
 
+	syncResourcesLister   clusterlister.ClusterSyncResourcesLister
+	syncResourcesInformer cache.SharedIndexInformer
+
+	options     Options
+	membership  *sharding.Membership
 	synchrolock sync.RWMutex
 	synchros    map[string]*clustersynchro.ClusterSynchro
 }
 
-func NewManager(kubeclient clientset.Interface, client crdclientset.Interface, storage storage.StorageFactory) *Manager {
+func NewManager(kubeclient clientset.Interface, client crdclientset.Interface, storage storage.StorageFactory, options Options) *Manager {
+	metrics.Register()
+
 	factory := externalversions.NewSharedInformerFactory(client, 0)
 	clusterinformer := factory.Clusters().V1alpha1().PediaClusters()
+	syncResourcesInformer := factory.Clusters().V1alpha1().ClusterSyncResourceses()
+
+	kubeFactory := informers.NewSharedInformerFactoryWithOptions(kubeclient, 0, informers.WithNamespace(options.ClusterSecretNamespace))
+	secretinformer := kubeFactory.Core().V1().Secrets()
 
 	manager := &Manager{
 		closer: make(chan struct{}),
 
-		informerFactory:    factory,
-		kubeclient:         kubeclient,
-		clusterpediaclient: client,
+		informerFactory:     factory,
+		kubeInformerFactory: kubeFactory,
+		kubeclient:          kubeclient,
+		clusterpediaclient:  client,
 
 		storage:         storage,
 		clusterlister:   clusterinformer.Lister(),
 		clusterInformer: clusterinformer.Informer(),
+		secretlister:    secretinformer.Lister(),
+		secretInformer:  secretinformer.Informer(),
+
+		syncResourcesLister:   syncResourcesInformer.Lister(),
+		syncResourcesInformer: syncResourcesInformer.Informer(),
+		// the retryable failures, eg. the storage is unavailable, are requeued with the exponential backoff
 		queue: workqueue.NewRateLimitingQueue(
-			workqueue.NewItemExponentialFailureRateLimiter(2*time.Second, 5*time.Second),
+			workqueue.NewItemExponentialFailureRateLimiter(2*time.Second, 5*time.Minute),
 		),
 
+		options:  options,
 		synchros: make(map[string]*clustersynchro.ClusterSynchro),
 	}
 
+	if options.Sharding.Enabled {
+		// the clusters are reassigned when the alive replicas are changed
+		manager.membership = sharding.NewMembership(kubeclient, options.Sharding, manager.enqueueAllClusters)
+	}
+
 	clusterinformer.Informer().AddEventHandler(
 		cache.ResourceEventHandlerFuncs{
 			AddFunc:    manager.addCluster,
@@ -76,16 +124,41 @@ func NewManager(kubeclient clientset.Interface, client crdclientset.Interface, s
 		},
 	)
 
+	secretinformer.Informer().AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    manager.addSecret,
+			UpdateFunc: manager.updateSecret,
+			DeleteFunc: manager.deleteSecret,
+		},
+	)
+
+	syncResourcesInformer.Informer().AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    manager.addSyncResources,
+			UpdateFunc: manager.updateSyncResources,
+			DeleteFunc: manager.deleteSyncResources,
+		},
+	)
+
 	return manager
 }
 
 func (manager *Manager) Run(workers int, stopCh <-chan struct{}) {
 	klog.Info("Start Informer Factory")
 	manager.informerFactory.Start(stopCh)
-	if !cache.WaitForCacheSync(stopCh, manager.clusterInformer.HasSynced) {
+	manager.kubeInformerFactory.Start(stopCh)
+	if !cache.WaitForCacheSync(stopCh, manager.clusterInformer.HasSynced, manager.secretInformer.HasSynced, manager.syncResourcesInformer.HasSynced) {
 		return
 	}
 
+	if manager.membership != nil {
+		go manager.membership.Run(stopCh)
+		if !cache.WaitForCacheSync(stopCh, manager.membership.HasSynced) {
+			return
+		}
+		defer manager.membership.Release()
+	}
+
 	klog.InfoS("Start Manager Cluster Worker", "workers", workers)
 
 	var waitGroup sync.WaitGroup
@@ -97,17 +170,41 @@ func (manager *Manager) Run(workers int, stopCh <-chan struct{}) {
 			wait.Until(manager.worker, time.Second, stopCh)
 		}()
 	}
-	// TODO(iceber): if stop synchro manager, need shutdown synchros
 
 	<-stopCh
 	klog.Info("receive stop signal, stop...")
 
 	manager.queue.ShutDown()
-
 	waitGroup.Wait()
+
+	manager.shutdownSynchros()
 	klog.Info("cluster synchro manager stoped.")
 }
 
+// shutdownSynchros gracefully shuts down all cluster synchros in parallel,
+// it is called after the workers are stopped, so no cluster synchros will be created.
+func (manager *Manager) shutdownSynchros() {
+	manager.synchrolock.Lock()
+	synchros := manager.synchros
+	manager.synchros = make(map[string]*clustersynchro.ClusterSynchro)
+	metrics.ClusterSynchros.Set(0)
+	manager.synchrolock.Unlock()
+
+	klog.InfoS("shutdown cluster synchros", "count", len(synchros), "timeout", manager.options.ShutdownTimeout)
+	ctx, cancel := context.WithTimeout(context.Background(), manager.options.ShutdownTimeout)
+	defer cancel()
+
+	var waitGroup sync.WaitGroup
+	for _, synchro := range synchros {
+		waitGroup.Add(1)
+		go func(synchro *clustersynchro.ClusterSynchro) {
+			defer waitGroup.Done()
+			synchro.GracefulShutdown(ctx)
+		}(synchro)
+	}
+	waitGroup.Wait()
+}
+
 func (manager *Manager) addCluster(obj interface{}) {
 	manager.enqueue(obj)
 }
@@ -126,6 +223,60 @@ func (manager *Manager) deleteCluster(obj interface{}) {
 	manager.enqueue(obj)
 }
 
+func (manager *Manager) addSecret(obj interface{}) {
+	manager.enqueueClustersForSecret(obj)
+}
+
+func (manager *Manager) updateSecret(older, newer interface{}) {
+	oldObj := older.(*corev1.Secret)
+	newObj := newer.(*corev1.Secret)
+	if equality.Semantic.DeepEqual(oldObj.Data, newObj.Data) {
+		return
+	}
+
+	manager.enqueueClustersForSecret(newer)
+}
+
+func (manager *Manager) deleteSecret(obj interface{}) {
+	manager.enqueueClustersForSecret(obj)
+}
+
+// enqueueClustersForSecret enqueues the clusters that reference the secret
+func (manager *Manager) enqueueClustersForSecret(obj interface{}) {
+	if d, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = d.Obj
+	}
+	secret, ok := obj.(*corev1.Secret)
+	if !ok {
+		return
+	}
+
+	clusters, err := manager.clusterlister.List(labels.Everything())
+	if err != nil {
+		klog.ErrorS(err, "Failed to list clusters", "secret", secret.Name)
+		return
+	}
+
+	for _, cluster := range clusters {
+		if cluster.Spec.SecretRef != nil && cluster.Spec.SecretRef.Name == secret.Name {
+			klog.V(2).InfoS("cluster secret is changed", "cluster", cluster.Name, "secret", secret.Name)
+			manager.enqueue(cluster)
+		}
+	}
+}
+
+func (manager *Manager) enqueueAllClusters() {
+	clusters, err := manager.clusterlister.List(labels.Everything())
+	if err != nil {
+		klog.ErrorS(err, "Failed to list clusters")
+		return
+	}
+
+	for _, cluster := range clusters {
+		manager.enqueue(cluster)
+	}
+}
+
 func (manager *Manager) enqueue(obj interface{}) {
 	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
 	if err != nil {
@@ -178,12 +329,39 @@ func (manager *Manager) processNextCluster() (continued bool) {
 
 // if err returned is not nil, cluster will be requeued
 func (manager *Manager) reconcileCluster(cluster *clustersv1alpha1.PediaCluster) (err error) {
+	if manager.membership != nil {
+		if !manager.membership.Owns(cluster.Name) {
+			// the cluster is assigned to other replica
+			manager.releaseCluster(cluster.Name)
+			return nil
+		}
+
+		// the cluster is synchronized only after the previous owner releases the cluster lease
+		acquired, err := manager.membership.AcquireCluster(context.TODO(), cluster.Name)
+		if err != nil || !acquired {
+			if !manager.membership.HoldsCluster(cluster.Name) {
+				manager.stopCluster(cluster.Name)
+			}
+			if err != nil {
+				klog.ErrorS(err, "Failed to acquire cluster lease", "cluster", cluster.Name)
+				return err
+			}
+
+			klog.V(2).InfoS("cluster lease is held by other replica, wait for the release", "cluster", cluster.Name)
+			manager.queue.AddAfter(cluster.Name, manager.options.Sharding.RenewInterval)
+			return nil
+		}
+	}
+
 	if !cluster.DeletionTimestamp.IsZero() {
 		klog.InfoS("remove cluster", "cluster", cluster.Name)
 		if err := manager.removeCluster(cluster.Name); err != nil {
 			klog.ErrorS(err, "Failed to remove cluster", cluster.Name)
 			return err
 		}
+		if manager.membership != nil {
+			manager.membership.ReleaseCluster(context.TODO(), cluster.Name, true)
+		}
 
 		if !controllerutil.ContainsFinalizer(cluster, ClusterSynchroControllerFinalizer) {
 			return nil
@@ -208,44 +386,93 @@ func (manager *Manager) reconcileCluster(cluster *clustersv1alpha1.PediaCluster)
 		}
 	}
 
-	config, err := buildClusterConfig(cluster)
+	config, err := manager.buildClusterConfig(cluster)
 	if err != nil {
-		// TODO(iceber): update cluster status
 		klog.ErrorS(err, "Failed to build cluster config", "cluster", cluster.Name)
+
+		// the running cluster synchro is stopped, the credentials of it are replaced by the invalid credentials,
+		// and the cluster is enqueued again when the cluster spec or the secret is changed.
+		manager.stopCluster(cluster.Name)
+		reason := clustersynchro.CreationReasonInvalidCredentials
+		if errors.Is(err, errInvalidProxyURL) {
+			reason = ClusterReasonInvalidProxyURL
+		}
+		manager.reportClusterFailure(cluster, reason, err)
+		return nil
+	}
+
+	// the cluster is enqueued again when the referenced sync resources are changed,
+	// and the synchronized resources are not changed until the sync resources are resolved.
+	resources, err := manager.resolveClusterResources(cluster)
+	if err != nil {
+		klog.ErrorS(err, "Failed to resolve cluster resources", "cluster", cluster.Name)
+
+		// the running cluster synchro keeps synchronizing the resources resolved last time,
+		// and its status is not overwritten by the failure.
+		manager.synchrolock.RLock()
+		synchro := manager.synchros[cluster.Name]
+		manager.synchrolock.RUnlock()
+		if synchro == nil {
+			reason := ClusterReasonSyncResourcesInvalid
+			if apierrors.IsNotFound(err) {
+				reason = ClusterReasonSyncResourcesNotFound
+			}
+			manager.reportClusterFailure(cluster, reason, err)
+		}
 		return nil
 	}
 
 	manager.synchrolock.RLock()
 	synchro := manager.synchros[cluster.Name]
 	manager.synchrolock.RUnlock()
-	if synchro != nil && !reflect.DeepEqual(synchro.RESTConfig, config) {
-		klog.InfoS("cluster config is changed, rebuild cluster synchro", "cluster", cluster.Name)
+	if synchro != nil && !clustersynchro.ConfigEqual(synchro.RESTConfig(), config) {
+		if synchro.RotateBearerToken(config) {
+			klog.InfoS("cluster bearer token is rotated", "cluster", cluster.Name)
+		} else {
+			klog.InfoS("cluster config is changed, rebuild cluster synchro", "cluster", cluster.Name)
 
-		synchro.Shutdown()
-		synchro = nil
+			manager.stopCluster(cluster.Name)
+			synchro = nil
 
-		// manager.cleanCluster(cluster.Name)
+			// manager.cleanCluster(cluster.Name)
+		}
 	}
 
 	// create resource synchro
 	if synchro == nil {
 		// TODO(iceber): set the stop sign of the manager to cluster synchro
-		synchro, err = clustersynchro.New(cluster.Name, config, manager.storage, manager)
+		// New probes the cluster, the unreachable cluster is reported with the retryable reason and requeued with backoff
+		synchro, err = clustersynchro.New(cluster.Name, config, manager.storage, manager, manager.options.Synchro)
 		if err != nil {
-			// TODO(iceber): update cluster status
-			// There are many reasons why creating a cluster synchro can fail.
-			// How do you gracefully handle different errors?
+			klog.ErrorS(err, "Failed to create cluster synchro", "cluster", cluster.Name)
 
-			klog.ErrorS(err, "Failed to cluster synchro", "cluster", cluster.Name)
-			// Not requeue
+			reason, retryable := ClusterReasonSynchroCreationFailed, false
+			var creationErr *clustersynchro.CreationError
+			if errors.As(err, &creationErr) {
+				reason, retryable = creationErr.Reason, creationErr.Retryable
+			}
+			manager.reportClusterFailure(cluster, reason, err)
+
+			if retryable {
+				// requeue with backoff
+				return err
+			}
 			return nil
 		}
 	}
 
-	synchro.SetResources(cluster.Spec.Resources)
+	var maxConcurrentLists int
+	if cluster.Spec.RateLimit != nil {
+		maxConcurrentLists = int(cluster.Spec.RateLimit.MaxConcurrentLists)
+	}
+	synchro.SetMaxConcurrentLists(maxConcurrentLists)
+	synchro.SetListConfig(cluster.Spec.List)
+	synchro.SetPaused(cluster.Spec.Paused)
+	synchro.SetResources(resources, cluster.Spec.Prune)
 
 	manager.synchrolock.Lock()
 	manager.synchros[cluster.Name] = synchro
+	metrics.ClusterSynchros.Set(float64(len(manager.synchros)))
 	manager.synchrolock.Unlock()
 	return nil
 }
@@ -254,6 +481,7 @@ func (manager *Manager) removeCluster(name string) error {
 	manager.synchrolock.Lock()
 	synchro := manager.synchros[name]
 	delete(manager.synchros, name)
+	metrics.ClusterSynchros.Set(float64(len(manager.synchros)))
 	manager.synchrolock.Unlock()
 
 	if synchro != nil {
@@ -262,6 +490,31 @@ func (manager *Manager) removeCluster(name string) error {
 	return manager.cleanCluster(name)
 }
 
+// releaseCluster shuts down the cluster synchro without cleaning the resources and releases the cluster lease,
+// the cluster will be synchronized by the replica that it is assigned to.
+func (manager *Manager) releaseCluster(name string) {
+	if manager.stopCluster(name) {
+		klog.InfoS("cluster is assigned to other replica, release cluster", "cluster", name, "owner", manager.membership.Owner(name))
+	}
+	manager.membership.ReleaseCluster(context.TODO(), name, false)
+}
+
+// stopCluster shuts down the cluster synchro without draining the queues,
+// the pending resources are synchronized again by the new owner.
+func (manager *Manager) stopCluster(name string) bool {
+	manager.synchrolock.Lock()
+	synchro := manager.synchros[name]
+	delete(manager.synchros, name)
+	metrics.ClusterSynchros.Set(float64(len(manager.synchros)))
+	manager.synchrolock.Unlock()
+	if synchro == nil {
+		return false
+	}
+
+	synchro.Shutdown()
+	return true
+}
+
 func (manager *Manager) cleanCluster(name string) error {
 	return manager.storage.CleanCluster(context.TODO(), name)
 }
@@ -272,7 +525,16 @@ func (manager *Manager) UpdateClusterStatus(ctx context.Context, name string, st
 		return err
 	}
 
-	if equality.Semantic.DeepEqual(cluster.Status, status) {
+	if manager.membership != nil {
+		// the status is updated by the replica that holds the cluster lease
+		if !manager.membership.HoldsCluster(name) {
+			klog.V(2).InfoS("cluster lease is not held, skip updating cluster status", "cluster", name)
+			return nil
+		}
+		status.Shard = manager.membership.Identity()
+	}
+
+	if equality.Semantic.DeepEqual(cluster.Status, *status) {
 		return nil
 	}
 
@@ -287,50 +549,204 @@ func (manager *Manager) UpdateClusterStatus(ctx context.Context, name string, st
 	return nil
 }
 
-func buildClusterConfig(cluster *clustersv1alpha1.PediaCluster) (*rest.Config, error) {
-	if cluster.Spec.APIServerURL == "" {
-		return nil, errors.New("Cluster APIServer Endpoint is required")
+// reportClusterFailure reports the failure to create the cluster synchro in the Ready condition of the cluster,
+// the cluster synchro should be stopped before reporting, otherwise the condition is overwritten by it.
+func (manager *Manager) reportClusterFailure(cluster *clustersv1alpha1.PediaCluster, reason string, err error) {
+	condition := metav1.Condition{
+		Type:               clustersv1alpha1.ClusterConditionReady,
+		Status:             metav1.ConditionFalse,
+		Reason:             reason,
+		Message:            err.Error(),
+		LastTransitionTime: metav1.Now(),
+	}
+	// the last transition time is kept if the status and the reason are not changed
+	if last := meta.FindStatusCondition(cluster.Status.Conditions, clustersv1alpha1.ClusterConditionReady); last != nil &&
+		last.Status == condition.Status && last.Reason == condition.Reason {
+		condition.LastTransitionTime = last.LastTransitionTime
 	}
 
-	if cluster.Spec.TokenData == "" && cluster.Spec.CAData == "" {
-		return nil, errors.New("Cluster APIServer's Token or CA is required")
+	status := cluster.Status.DeepCopy()
+	status.Conditions = []metav1.Condition{condition}
+	if err := manager.UpdateClusterStatus(context.TODO(), cluster.Name, status); err != nil {
+		klog.ErrorS(err, "Failed to update cluster status", "cluster", cluster.Name, "reason", reason)
 	}
+}
 
-	config := &rest.Config{
-		Host: cluster.Spec.APIServerURL,
+func (manager *Manager) buildClusterConfig(cluster *clustersv1alpha1.PediaCluster) (*rest.Config, error) {
+	var config *rest.Config
+	var err error
+	if cluster.Spec.SecretRef != nil {
+		config, err = manager.buildClusterConfigFromSecret(cluster)
+	} else if err = manager.checkLocalCredentials(cluster); err == nil {
+		config, err = buildClusterConfigFromSpec(cluster)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if cluster.Spec.ProxyURL != "" {
+		proxyURL, err := url.Parse(cluster.Spec.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", errInvalidProxyURL, err)
+		}
+
+		switch proxyURL.Scheme {
+		case "http", "https", "socks5":
+		default:
+			return nil, fmt.Errorf("%w: unsupported scheme %q", errInvalidProxyURL, proxyURL.Scheme)
+		}
+		config.Proxy = http.ProxyURL(proxyURL)
+	}
+	if cluster.Spec.TLSServerName != "" {
+		config.TLSClientConfig.ServerName = cluster.Spec.TLSServerName
+	}
+
+	// the rate limits of the manager are used by the cluster synchro if they are not set
+	config.QPS, config.Burst = 0, 0
+	if rateLimit := cluster.Spec.RateLimit; rateLimit != nil {
+		config.QPS = float32(rateLimit.QPS)
+		config.Burst = int(rateLimit.Burst)
+	}
+	return config, nil
+}
+
+func buildClusterConfigFromSpec(cluster *clustersv1alpha1.PediaCluster) (*rest.Config, error) {
+	if cluster.Spec.APIServerURL == "" {
+		return nil, errors.New("Cluster APIServer Endpoint is required")
 	}
 
+	var token, ca, cert, key []byte
 	if cluster.Spec.CAData != "" {
-		ca, err := base64.StdEncoding.DecodeString(cluster.Spec.CAData)
+		var err error
+		ca, err = base64.StdEncoding.DecodeString(cluster.Spec.CAData)
 		if err != nil {
 			return nil, fmt.Errorf("Cluster CA is invalid: %v", err)
 		}
-		config.TLSClientConfig.CAData = ca
 
 		if cluster.Spec.CertData != "" && cluster.Spec.KeyData != "" {
-			cert, err := base64.StdEncoding.DecodeString(cluster.Spec.CertData)
+			cert, err = base64.StdEncoding.DecodeString(cluster.Spec.CertData)
 			if err != nil {
 				return nil, fmt.Errorf("Cluster Cert is invalid: %v", err)
 			}
-			key, err := base64.StdEncoding.DecodeString(cluster.Spec.KeyData)
+			key, err = base64.StdEncoding.DecodeString(cluster.Spec.KeyData)
 			if err != nil {
 				return nil, fmt.Errorf("Cluster Cert is invalid: %v", err)
 			}
-
-			config.TLSClientConfig.CertData = cert
-			config.TLSClientConfig.KeyData = key
 		}
-	} else {
-		config.TLSClientConfig.Insecure = true
 	}
 
 	if cluster.Spec.TokenData != "" {
-		token, err := base64.StdEncoding.DecodeString(cluster.Spec.TokenData)
+		var err error
+		token, err = base64.StdEncoding.DecodeString(cluster.Spec.TokenData)
 		if err != nil {
 			return nil, fmt.Errorf("Cluster CA is invalid: %v", err)
 		}
+	}
 
-		config.BearerToken = string(token)
+	config := newClusterConfig(cluster.Spec.APIServerURL, token, ca, cert, key)
+
+	// the token and client certificate files are reloaded by the client when they are rotated
+	config.BearerTokenFile = cluster.Spec.TokenFile
+	if cluster.Spec.CAFile != "" {
+		config.TLSClientConfig.CAFile = cluster.Spec.CAFile
+		config.TLSClientConfig.Insecure = false
+	}
+	if cluster.Spec.CertFile != "" && cluster.Spec.KeyFile != "" {
+		config.TLSClientConfig.CertFile = cluster.Spec.CertFile
+		config.TLSClientConfig.KeyFile = cluster.Spec.KeyFile
+	}
+	if exec := cluster.Spec.ExecProvider; exec != nil {
+		config.ExecProvider = &clientcmdapi.ExecConfig{
+			Command:            exec.Command,
+			Args:               exec.Args,
+			APIVersion:         exec.APIVersion,
+			ProvideClusterInfo: exec.ProvideClusterInfo,
+			InteractiveMode:    clientcmdapi.NeverExecInteractiveMode,
+		}
+		for _, env := range exec.Env {
+			config.ExecProvider.Env = append(config.ExecProvider.Env, clientcmdapi.ExecEnvVar{Name: env.Name, Value: env.Value})
+		}
+	}
+
+	if err := validateClusterConfig(config); err != nil {
+		return nil, err
 	}
 	return config, nil
 }
+
+const (
+	ClusterSecretKubeconfig = "kubeconfig"
+	ClusterSecretToken      = "token"
+	ClusterSecretCA         = "ca.crt"
+	ClusterSecretCert       = "tls.crt"
+	ClusterSecretKey        = "tls.key"
+)
+
+func (manager *Manager) buildClusterConfigFromSecret(cluster *clustersv1alpha1.PediaCluster) (*rest.Config, error) {
+	ref := cluster.Spec.SecretRef
+	secret, err := manager.secretlister.Secrets(manager.options.ClusterSecretNamespace).Get(ref.Name)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get cluster secret %s/%s: %w", manager.options.ClusterSecretNamespace, ref.Name, err)
+	}
+
+	if kubeconfig, ok := secret.Data[ClusterSecretKubeconfig]; ok {
+		clientConfig, err := clientcmd.Load(kubeconfig)
+		if err != nil {
+			return nil, fmt.Errorf("Cluster kubeconfig is invalid: %v", err)
+		}
+
+		overrides := &clientcmd.ConfigOverrides{}
+		if cluster.Spec.APIServerURL != "" {
+			overrides.ClusterInfo.Server = cluster.Spec.APIServerURL
+		}
+		config, err := clientcmd.NewNonInteractiveClientConfig(*clientConfig, ref.Context, overrides, nil).ClientConfig()
+		if err != nil {
+			return nil, fmt.Errorf("Cluster kubeconfig is invalid: %v", err)
+		}
+		return config, nil
+	}
+
+	if cluster.Spec.APIServerURL == "" {
+		return nil, errors.New("Cluster APIServer Endpoint is required")
+	}
+
+	var cert, key []byte
+	if secret.Data[ClusterSecretCert] != nil && secret.Data[ClusterSecretKey] != nil {
+		cert, key = secret.Data[ClusterSecretCert], secret.Data[ClusterSecretKey]
+	}
+	config := newClusterConfig(cluster.Spec.APIServerURL, secret.Data[ClusterSecretToken], secret.Data[ClusterSecretCA], cert, key)
+	if err := validateClusterConfig(config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func newClusterConfig(host string, token, ca, cert, key []byte) *rest.Config {
+	config := &rest.Config{
+		Host: host,
+	}
+
+	if len(ca) != 0 {
+		config.TLSClientConfig.CAData = ca
+		if len(cert) != 0 && len(key) != 0 {
+			config.TLSClientConfig.CertData = cert
+			config.TLSClientConfig.KeyData = key
+		}
+	} else {
+		config.TLSClientConfig.Insecure = true
+	}
+
+	if len(token) != 0 {
+		config.BearerToken = string(token)
+	}
+	return config
+}
+
+func validateClusterConfig(config *rest.Config) error {
+	hasCA := len(config.TLSClientConfig.CAData) != 0 || config.TLSClientConfig.CAFile != ""
+	hasCredential := config.BearerToken != "" || config.BearerTokenFile != "" || config.ExecProvider != nil
+	if !hasCA && !hasCredential {
+		return errors.New("Cluster APIServer's Token or CA is required")
+	}
+	return nil
+}
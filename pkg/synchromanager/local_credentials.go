@@ -0,0 +1,68 @@
+package synchromanager
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	clustersv1alpha1 "github.com/clusterpedia-io/clusterpedia/pkg/apis/clusters/v1alpha1"
+)
+
+// checkLocalCredentials checks the exec provider and the credential files of the cluster spec,
+// they are run or read on the manager, so they are rejected unless they are allowed by the manager.
+// The credentials in the kubeconfig of the cluster secret are trusted, since the secrets are managed by the administrator.
+func (manager *Manager) checkLocalCredentials(cluster *clustersv1alpha1.PediaCluster) error {
+	if exec := cluster.Spec.ExecProvider; exec != nil {
+		if !isAllowedCommand(manager.options.AllowedExecCommands, exec.Command) {
+			return fmt.Errorf("Cluster exec provider command %q is not allowed by the clustersynchro manager", exec.Command)
+		}
+	}
+
+	files := []struct {
+		field string
+		path  string
+	}{
+		{"tokenFile", cluster.Spec.TokenFile},
+		{"caFile", cluster.Spec.CAFile},
+		{"certFile", cluster.Spec.CertFile},
+		{"keyFile", cluster.Spec.KeyFile},
+	}
+	for _, file := range files {
+		if file.path == "" {
+			continue
+		}
+		if !isAllowedPath(manager.options.AllowedCredentialDirs, file.path) {
+			return fmt.Errorf("Cluster %s %q is not allowed by the clustersynchro manager", file.field, file.path)
+		}
+	}
+	return nil
+}
+
+func isAllowedCommand(allowed []string, command string) bool {
+	for _, c := range allowed {
+		if c == command {
+			return true
+		}
+	}
+	return false
+}
+
+// isAllowedPath returns true if the path is in one of the allowed directories,
+// the symbolic links of the path are resolved if the path exists.
+func isAllowedPath(allowedDirs []string, path string) bool {
+	if !filepath.IsAbs(path) {
+		return false
+	}
+
+	path = filepath.Clean(path)
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+	for _, dir := range allowedDirs {
+		dir = filepath.Clean(dir)
+		if dir == string(filepath.Separator) || strings.HasPrefix(path, dir+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
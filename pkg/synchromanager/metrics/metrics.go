@@ -0,0 +1,180 @@
+package metrics
+
+import (
+	"sync"
+
+	"k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+)
+
+const (
+	namespace = "clusterpedia"
+	subsystem = "clustersynchro"
+)
+
+var (
+	// ResourceQueueDepth is the number of the resource keys that are pending or processing in the queue
+	ResourceQueueDepth = metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Namespace:      namespace,
+			Subsystem:      subsystem,
+			Name:           "resource_queue_depth",
+			Help:           "Number of the resource keys that are pending or processing in the queue of the resource synchro.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"cluster", "resource", "version"},
+	)
+
+	// ResourceEventsAdded is the number of the events that are added to the queue
+	ResourceEventsAdded = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Namespace:      namespace,
+			Subsystem:      subsystem,
+			Name:           "resource_events_added_total",
+			Help:           "Number of the resource events added to the queue of the resource synchro.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"cluster", "resource", "version", "action"},
+	)
+
+	// ResourceEventsCompressed is the number of the events that are compressed with the pending events of the same key
+	ResourceEventsCompressed = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Namespace:      namespace,
+			Subsystem:      subsystem,
+			Name:           "resource_events_compressed_total",
+			Help:           "Number of the resource events compressed with the pending events of the same resource.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"cluster", "resource", "version"},
+	)
+
+	// ResourceEventsProcessed is the number of the events that are written to the storage
+	ResourceEventsProcessed = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Namespace:      namespace,
+			Subsystem:      subsystem,
+			Name:           "resource_events_processed_total",
+			Help:           "Number of the resource events written to the storage.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"cluster", "resource", "version"},
+	)
+
+	// StorageWriteDuration is the latency of the storage writes
+	StorageWriteDuration = metrics.NewHistogramVec(
+		&metrics.HistogramOpts{
+			Namespace:      namespace,
+			Subsystem:      subsystem,
+			Name:           "storage_write_duration_seconds",
+			Help:           "Latency of the storage writes in seconds by action.",
+			Buckets:        metrics.ExponentialBuckets(0.001, 2, 15),
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"cluster", "resource", "version", "action"},
+	)
+
+	// StorageWriteErrors is the number of the failed storage writes
+	StorageWriteErrors = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Namespace:      namespace,
+			Subsystem:      subsystem,
+			Name:           "storage_write_errors_total",
+			Help:           "Number of the failed storage writes by action.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"cluster", "resource", "version", "action"},
+	)
+
+	// InformerRelists is the number of the lists of the resource informer
+	InformerRelists = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Namespace:      namespace,
+			Subsystem:      subsystem,
+			Name:           "informer_relists_total",
+			Help:           "Number of the lists of the resource informer, including the initial list.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"cluster", "resource", "version"},
+	)
+
+	// ClusterHealthChecks is the number of the cluster health checks by result
+	ClusterHealthChecks = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Namespace:      namespace,
+			Subsystem:      subsystem,
+			Name:           "cluster_health_checks_total",
+			Help:           "Number of the cluster health checks by result.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"cluster", "result"},
+	)
+
+	// ClusterHealthCheckDuration is the latency of the cluster health checks
+	ClusterHealthCheckDuration = metrics.NewHistogramVec(
+		&metrics.HistogramOpts{
+			Namespace:      namespace,
+			Subsystem:      subsystem,
+			Name:           "cluster_health_check_duration_seconds",
+			Help:           "Latency of the cluster health checks in seconds.",
+			Buckets:        metrics.ExponentialBuckets(0.005, 2, 12),
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"cluster"},
+	)
+
+	// ClusterStatusUpdateFailures is the number of the failed cluster status updates
+	ClusterStatusUpdateFailures = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Namespace:      namespace,
+			Subsystem:      subsystem,
+			Name:           "cluster_status_update_failures_total",
+			Help:           "Number of the failed status updates of the PediaCluster.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"cluster"},
+	)
+
+	// ResourceSynchros is the number of the active resource synchros of the cluster
+	ResourceSynchros = metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Namespace:      namespace,
+			Subsystem:      subsystem,
+			Name:           "resource_synchros",
+			Help:           "Number of the active resource synchros of the cluster.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"cluster"},
+	)
+
+	// ClusterSynchros is the number of the active cluster synchros of the manager
+	ClusterSynchros = metrics.NewGauge(
+		&metrics.GaugeOpts{
+			Namespace:      namespace,
+			Subsystem:      subsystem,
+			Name:           "cluster_synchros",
+			Help:           "Number of the active cluster synchros of the manager.",
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+)
+
+var registerOnce sync.Once
+
+// Register registers the metrics of the synchro manager to the legacy registry
+func Register() {
+	registerOnce.Do(func() {
+		legacyregistry.MustRegister(ResourceQueueDepth)
+		legacyregistry.MustRegister(ResourceEventsAdded)
+		legacyregistry.MustRegister(ResourceEventsCompressed)
+		legacyregistry.MustRegister(ResourceEventsProcessed)
+		legacyregistry.MustRegister(StorageWriteDuration)
+		legacyregistry.MustRegister(StorageWriteErrors)
+		legacyregistry.MustRegister(InformerRelists)
+		legacyregistry.MustRegister(ClusterHealthChecks)
+		legacyregistry.MustRegister(ClusterHealthCheckDuration)
+		legacyregistry.MustRegister(ClusterStatusUpdateFailures)
+		legacyregistry.MustRegister(ResourceSynchros)
+		legacyregistry.MustRegister(ClusterSynchros)
+	})
+}
@@ -0,0 +1,41 @@
+package synchromanager
+
+import (
+	"time"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/synchromanager/clustersynchro"
+	"github.com/clusterpedia-io/clusterpedia/pkg/synchromanager/sharding"
+)
+
+const (
+	DefaultClusterSecretNamespace = "clusterpedia-system"
+	DefaultShutdownTimeout        = 10 * time.Second
+)
+
+type Options struct {
+	// ClusterSecretNamespace is the namespace of the secrets referenced by the PediaClusters
+	ClusterSecretNamespace string
+
+	// ShutdownTimeout is how long to wait for the pending resources to be stored when the manager is stopped
+	ShutdownTimeout time.Duration
+
+	// AllowedExecCommands are the commands that can be used by the exec providers of the PediaClusters,
+	// the exec providers are rejected if it is empty.
+	AllowedExecCommands []string
+
+	// AllowedCredentialDirs are the directories that the credential files of the PediaClusters can be in,
+	// the credential files are rejected if it is empty.
+	AllowedCredentialDirs []string
+
+	Synchro  clustersynchro.Options
+	Sharding sharding.Options
+}
+
+func NewOptions() Options {
+	return Options{
+		ClusterSecretNamespace: DefaultClusterSecretNamespace,
+		ShutdownTimeout:        DefaultShutdownTimeout,
+		Synchro:                clustersynchro.NewOptions(),
+		Sharding:               sharding.NewOptions(),
+	}
+}
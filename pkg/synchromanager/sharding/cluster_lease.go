@@ -0,0 +1,199 @@
+package sharding
+
+import (
+	"context"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+// ClusterLeaseLabel is set on the leases of the clusters
+const ClusterLeaseLabel = "clusterpedia.io/clustersynchro-manager-cluster"
+
+const clusterLeasePrefix = "pediacluster-"
+
+// heldLease is a cluster lease held by the replica
+type heldLease struct {
+	lease     *coordinationv1.Lease
+	renewTime time.Time
+}
+
+func clusterLeaseName(cluster string) string {
+	return clusterLeasePrefix + cluster
+}
+
+// AcquireCluster acquires or renews the lease of the cluster, the cluster can be synchronized only if its lease is held.
+// The lease held by other replica can be acquired only after it is released or expired,
+// so that the new owner does not synchronize the cluster until the previous owner stops synchronizing it.
+func (m *Membership) AcquireCluster(ctx context.Context, cluster string) (bool, error) {
+	m.lock.RLock()
+	held := m.held[cluster]
+	m.lock.RUnlock()
+
+	if held != nil {
+		acquired, err := m.renewClusterLease(ctx, cluster, held.lease.DeepCopy())
+		if err == nil || !apierrors.IsConflict(err) {
+			return acquired, err
+		}
+		// the lease is changed by others, check the holder again
+	}
+
+	lease, err := m.client.CoordinationV1().Leases(m.options.Namespace).Get(ctx, clusterLeaseName(cluster), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return m.createClusterLease(ctx, cluster)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if holder := lease.Spec.HolderIdentity; holder != nil && *holder != "" && *holder != m.identity {
+		if expireTime, ok := leaseExpireTime(lease); ok && time.Now().Before(expireTime) {
+			m.forgetCluster(cluster)
+			return false, nil
+		}
+		klog.InfoS("cluster lease of other replica is expired, take over the cluster", "cluster", cluster, "holder", *holder)
+	}
+	acquired, err := m.renewClusterLease(ctx, cluster, lease)
+	if apierrors.IsConflict(err) {
+		// the lease is acquired by other replica
+		return false, nil
+	}
+	return acquired, err
+}
+
+func (m *Membership) createClusterLease(ctx context.Context, cluster string) (bool, error) {
+	now := metav1.NewMicroTime(time.Now())
+	durationSeconds := int32(m.options.LeaseDuration.Seconds())
+	lease := &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      clusterLeaseName(cluster),
+			Namespace: m.options.Namespace,
+			Labels:    map[string]string{ClusterLeaseLabel: ""},
+		},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity:       &m.identity,
+			LeaseDurationSeconds: &durationSeconds,
+			AcquireTime:          &now,
+			RenewTime:            &now,
+		},
+	}
+
+	lease, err := m.client.CoordinationV1().Leases(m.options.Namespace).Create(ctx, lease, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	m.holdCluster(cluster, lease, now.Time)
+	return true, nil
+}
+
+func (m *Membership) renewClusterLease(ctx context.Context, cluster string, lease *coordinationv1.Lease) (bool, error) {
+	now := metav1.NewMicroTime(time.Now())
+	durationSeconds := int32(m.options.LeaseDuration.Seconds())
+	if lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity != m.identity {
+		lease.Spec.HolderIdentity = &m.identity
+		lease.Spec.AcquireTime = &now
+	}
+	lease.Spec.LeaseDurationSeconds = &durationSeconds
+	lease.Spec.RenewTime = &now
+
+	lease, err := m.client.CoordinationV1().Leases(m.options.Namespace).Update(ctx, lease, metav1.UpdateOptions{})
+	if err != nil {
+		return false, err
+	}
+	m.holdCluster(cluster, lease, now.Time)
+	return true, nil
+}
+
+// HoldsCluster returns true if the lease of the cluster is held by the replica and it is not expired
+func (m *Membership) HoldsCluster(cluster string) bool {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	held := m.held[cluster]
+	return held != nil && time.Now().Before(held.renewTime.Add(m.options.LeaseDuration))
+}
+
+// ReleaseCluster releases the lease of the cluster, so that the new owner can synchronize the cluster immediately,
+// it should be called after the cluster synchro is stopped.
+// The lease is deleted if deleteLease is true, eg. the cluster is deleted.
+func (m *Membership) ReleaseCluster(ctx context.Context, cluster string, deleteLease bool) {
+	m.lock.RLock()
+	held := m.held[cluster]
+	m.lock.RUnlock()
+	if held == nil {
+		return
+	}
+	m.forgetCluster(cluster)
+
+	leases := m.client.CoordinationV1().Leases(m.options.Namespace)
+	var err error
+	if deleteLease {
+		// the lease is deleted only if it is not acquired by others
+		err = leases.Delete(ctx, held.lease.Name, metav1.DeleteOptions{
+			Preconditions: &metav1.Preconditions{ResourceVersion: &held.lease.ResourceVersion},
+		})
+	} else {
+		lease := held.lease.DeepCopy()
+		lease.Spec.HolderIdentity = nil
+		_, err = leases.Update(ctx, lease, metav1.UpdateOptions{})
+	}
+	if err != nil && !apierrors.IsNotFound(err) && !apierrors.IsConflict(err) {
+		klog.ErrorS(err, "Failed to release cluster lease", "cluster", cluster)
+		return
+	}
+	klog.V(2).InfoS("cluster lease is released", "cluster", cluster)
+}
+
+// releaseClusters releases the leases of all held clusters
+func (m *Membership) releaseClusters(ctx context.Context) {
+	m.lock.RLock()
+	clusters := make([]string, 0, len(m.held))
+	for cluster := range m.held {
+		clusters = append(clusters, cluster)
+	}
+	m.lock.RUnlock()
+
+	for _, cluster := range clusters {
+		m.ReleaseCluster(ctx, cluster, false)
+	}
+}
+
+// renewClusters renews the leases of the held clusters, and returns true if any lease is lost
+func (m *Membership) renewClusters(ctx context.Context) (lost bool) {
+	m.lock.RLock()
+	clusters := make([]string, 0, len(m.held))
+	for cluster := range m.held {
+		clusters = append(clusters, cluster)
+	}
+	m.lock.RUnlock()
+
+	for _, cluster := range clusters {
+		acquired, err := m.AcquireCluster(ctx, cluster)
+		if err != nil {
+			klog.ErrorS(err, "Failed to renew cluster lease", "cluster", cluster)
+		}
+		if !acquired && !m.HoldsCluster(cluster) {
+			klog.InfoS("cluster lease is lost", "cluster", cluster)
+			lost = true
+		}
+	}
+	return lost
+}
+
+func (m *Membership) holdCluster(cluster string, lease *coordinationv1.Lease, renewTime time.Time) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.held[cluster] = &heldLease{lease: lease, renewTime: renewTime}
+}
+
+func (m *Membership) forgetCluster(cluster string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	delete(m.held, cluster)
+}
@@ -0,0 +1,202 @@
+package sharding
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+func TestAcquireCluster(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name         string
+		lease        *coordinationv1.Lease
+		wantAcquired bool
+		wantHolder   string
+	}{
+		{
+			name:         "lease does not exist",
+			wantAcquired: true,
+			wantHolder:   testIdentity,
+		},
+		{
+			name:         "lease is held by the replica",
+			lease:        newTestLease(clusterLeaseName("cluster-1"), ClusterLeaseLabel, testIdentity, now),
+			wantAcquired: true,
+			wantHolder:   testIdentity,
+		},
+		{
+			name:         "lease is held by other replica",
+			lease:        newTestLease(clusterLeaseName("cluster-1"), ClusterLeaseLabel, "replica-b", now),
+			wantAcquired: false,
+			wantHolder:   "replica-b",
+		},
+		{
+			name:         "lease of other replica is expired",
+			lease:        newTestLease(clusterLeaseName("cluster-1"), ClusterLeaseLabel, "replica-b", now.Add(-2*testLeaseDuration)),
+			wantAcquired: true,
+			wantHolder:   testIdentity,
+		},
+		{
+			name:         "lease is released by other replica",
+			lease:        newTestLease(clusterLeaseName("cluster-1"), ClusterLeaseLabel, "", now),
+			wantAcquired: true,
+			wantHolder:   testIdentity,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := fake.NewSimpleClientset()
+			if test.lease != nil {
+				client = fake.NewSimpleClientset(test.lease)
+			}
+			m := newTestMembership(client, nil)
+
+			acquired, err := m.AcquireCluster(context.TODO(), "cluster-1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if acquired != test.wantAcquired {
+				t.Errorf("AcquireCluster() = %v, want %v", acquired, test.wantAcquired)
+			}
+			if got := m.HoldsCluster("cluster-1"); got != test.wantAcquired {
+				t.Errorf("HoldsCluster() = %v, want %v", got, test.wantAcquired)
+			}
+
+			lease, err := client.CoordinationV1().Leases(testNamespace).Get(context.TODO(), clusterLeaseName("cluster-1"), metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var holder string
+			if lease.Spec.HolderIdentity != nil {
+				holder = *lease.Spec.HolderIdentity
+			}
+			if holder != test.wantHolder {
+				t.Errorf("lease holder = %q, want %q", holder, test.wantHolder)
+			}
+		})
+	}
+}
+
+func TestHoldsClusterAfterRenewalFails(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name string
+		// renewAge is how long ago the lease was renewed by the replica
+		renewAge time.Duration
+		// takenBy is the replica that takes over the lease before the renewal
+		takenBy   string
+		wantHolds bool
+		wantLost  bool
+	}{
+		{
+			name:      "lease is not expired",
+			renewAge:  testLeaseDuration / 3,
+			wantHolds: true,
+		},
+		{
+			name:     "lease is expired",
+			renewAge: 2 * testLeaseDuration,
+			wantLost: true,
+		},
+		{
+			name:     "lease is taken over by other replica",
+			renewAge: testLeaseDuration / 3,
+			takenBy:  "replica-b",
+			wantLost: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := fake.NewSimpleClientset()
+			m := newTestMembership(client, nil)
+			if acquired, err := m.AcquireCluster(context.TODO(), "cluster-1"); err != nil || !acquired {
+				t.Fatalf("AcquireCluster() = %v, %v, want acquired", acquired, err)
+			}
+			m.lock.Lock()
+			m.held["cluster-1"].renewTime = now.Add(-test.renewAge)
+			m.lock.Unlock()
+
+			if test.takenBy != "" {
+				lease := newTestLease(clusterLeaseName("cluster-1"), ClusterLeaseLabel, test.takenBy, now)
+				gvr := schema.GroupVersionResource{Group: coordinationv1.GroupName, Version: "v1", Resource: "leases"}
+				if err := client.Tracker().Update(gvr, lease, testNamespace); err != nil {
+					t.Fatal(err)
+				}
+				// the renewal with the held lease conflicts with the lease of other replica
+				client.PrependReactor("update", "leases", func(action clienttesting.Action) (bool, runtime.Object, error) {
+					return true, nil, apierrors.NewConflict(gvr.GroupResource(), lease.Name, nil)
+				})
+			} else {
+				failLeases(client, "update")
+			}
+
+			if lost := m.renewClusters(context.TODO()); lost != test.wantLost {
+				t.Errorf("renewClusters() = %v, want %v", lost, test.wantLost)
+			}
+			if got := m.HoldsCluster("cluster-1"); got != test.wantHolds {
+				t.Errorf("HoldsCluster() = %v, want %v", got, test.wantHolds)
+			}
+		})
+	}
+}
+
+func TestReleaseCluster(t *testing.T) {
+	tests := []struct {
+		name        string
+		deleteLease bool
+	}{
+		{name: "release lease"},
+		{name: "delete lease", deleteLease: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := fake.NewSimpleClientset()
+			m := newTestMembership(client, nil)
+			if acquired, err := m.AcquireCluster(context.TODO(), "cluster-1"); err != nil || !acquired {
+				t.Fatalf("AcquireCluster() = %v, %v, want acquired", acquired, err)
+			}
+
+			m.ReleaseCluster(context.TODO(), "cluster-1", test.deleteLease)
+			if m.HoldsCluster("cluster-1") {
+				t.Errorf("HoldsCluster() = true after the lease is released")
+			}
+
+			lease, err := client.CoordinationV1().Leases(testNamespace).Get(context.TODO(), clusterLeaseName("cluster-1"), metav1.GetOptions{})
+			if test.deleteLease {
+				if !apierrors.IsNotFound(err) {
+					t.Errorf("lease is not deleted, error = %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if lease.Spec.HolderIdentity != nil {
+				t.Errorf("lease holder = %q, want released", *lease.Spec.HolderIdentity)
+			}
+
+			// the released lease can be acquired by other replica immediately
+			other := NewMembership(client, Options{
+				Identity:      "replica-b",
+				Namespace:     testNamespace,
+				LeaseDuration: testLeaseDuration,
+				RenewInterval: 5 * time.Second,
+			}, nil)
+			if acquired, err := other.AcquireCluster(context.TODO(), "cluster-1"); err != nil || !acquired {
+				t.Errorf("AcquireCluster() of other replica = %v, %v, want acquired", acquired, err)
+			}
+		})
+	}
+}
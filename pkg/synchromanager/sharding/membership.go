@@ -0,0 +1,273 @@
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+)
+
+// ShardLabel is set on the leases of the replicas
+const ShardLabel = "clusterpedia.io/clustersynchro-manager-shard"
+
+// Membership registers the replica with a lease and tracks the alive replicas,
+// the clusters are assigned to the alive replicas by rendezvous hashing,
+// so that only the clusters of a dead or new replica are moved.
+type Membership struct {
+	identity string
+	options  Options
+	client   clientset.Interface
+
+	// onChange is called when the alive replicas are changed or the cluster leases are lost
+	onChange func()
+
+	lock      sync.RWMutex
+	synced    bool
+	members   []string
+	lastRenew time.Time
+
+	// held is the leases of the clusters synchronized by the replica
+	held map[string]*heldLease
+}
+
+func NewMembership(client clientset.Interface, options Options, onChange func()) *Membership {
+	identity := options.Identity
+	if identity == "" {
+		identity = string(uuid.NewUUID())[:8]
+		if hostname, err := os.Hostname(); err == nil {
+			identity = strings.ToLower(hostname) + "-" + identity
+		}
+	}
+
+	return &Membership{
+		identity: identity,
+		options:  options,
+		client:   client,
+		onChange: onChange,
+		held:     make(map[string]*heldLease),
+	}
+}
+
+// Identity returns the identity of the replica
+func (m *Membership) Identity() string {
+	return m.identity
+}
+
+func (m *Membership) HasSynced() bool {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.synced
+}
+
+// Owner returns the replica that the cluster is assigned to,
+// it returns empty if there are no alive replicas.
+func (m *Membership) Owner(cluster string) string {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	var owner string
+	var maxScore uint64
+	for _, member := range m.members {
+		hash := fnv.New64a()
+		hash.Write([]byte(member))
+		hash.Write([]byte{'/'})
+		hash.Write([]byte(cluster))
+		if score := mixScore(hash.Sum64()); owner == "" || score > maxScore {
+			owner, maxScore = member, score
+		}
+	}
+	return owner
+}
+
+// mixScore mixes the bits of the fnv hash with the finalizer of murmur3,
+// the fnv hashes of the keys with the same suffix are ordered nearly the same for all clusters,
+// so that the clusters would be assigned to only a few replicas without mixing.
+func mixScore(h uint64) uint64 {
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
+
+// Owns returns true if the cluster is assigned to the replica
+func (m *Membership) Owns(cluster string) bool {
+	return m.Owner(cluster) == m.identity
+}
+
+// Run renews the lease of the replica and refreshes the alive replicas until stopCh is closed
+func (m *Membership) Run(stopCh <-chan struct{}) {
+	klog.InfoS("Start shard membership", "identity", m.identity, "namespace", m.options.Namespace)
+	wait.Until(m.refresh, m.options.RenewInterval, stopCh)
+}
+
+// Release releases the leases of the clusters and deletes the lease of the replica,
+// so that the clusters of the replica are moved immediately,
+// it should be called after the cluster synchros of the replica are shut down.
+func (m *Membership) Release() {
+	ctx, cancel := context.WithTimeout(context.Background(), m.options.RenewInterval)
+	defer cancel()
+	m.releaseClusters(ctx)
+	if err := m.client.CoordinationV1().Leases(m.options.Namespace).Delete(ctx, m.identity, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		klog.ErrorS(err, "Failed to delete shard lease", "identity", m.identity)
+		return
+	}
+	klog.InfoS("shard lease is released", "identity", m.identity)
+}
+
+func (m *Membership) refresh() {
+	ctx, cancel := context.WithTimeout(context.Background(), m.options.RenewInterval)
+	defer cancel()
+
+	if err := m.renew(ctx); err != nil {
+		klog.ErrorS(err, "Failed to renew shard lease", "identity", m.identity)
+	} else {
+		m.lock.Lock()
+		m.lastRenew = time.Now()
+		m.lock.Unlock()
+	}
+
+	// the cluster synchros must be stopped if their cluster leases are lost
+	clustersLost := m.renewClusters(ctx)
+
+	members, err := m.listAliveMembers(ctx)
+	if err != nil {
+		// the other replicas are kept as they are, but the replica itself is still dropped if its lease is expired
+		klog.ErrorS(err, "Failed to list shard leases")
+	}
+
+	now := time.Now()
+	m.lock.Lock()
+	if err != nil {
+		members = make([]string, 0, len(m.members))
+		for _, member := range m.members {
+			if member != m.identity {
+				members = append(members, member)
+			}
+		}
+	}
+	if now.Before(m.lastRenew.Add(m.options.LeaseDuration)) {
+		members = append(members, m.identity)
+	}
+	sort.Strings(members)
+	// the members are not synced until the shard leases are listed
+	changed := (err == nil && !m.synced) || !stringSliceEqual(m.members, members)
+	m.members = members
+	if err == nil {
+		m.synced = true
+	}
+	m.lock.Unlock()
+
+	if changed {
+		klog.InfoS("shard members are changed", "identity", m.identity, "members", members)
+	}
+	if (changed || clustersLost) && m.onChange != nil {
+		m.onChange()
+	}
+}
+
+// listAliveMembers lists the other replicas whose leases are not expired,
+// and deletes the leases of the replicas which have been dead for a while.
+func (m *Membership) listAliveMembers(ctx context.Context) ([]string, error) {
+	leases, err := m.client.CoordinationV1().Leases(m.options.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{ShardLabel: ""}).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var members []string
+	for _, lease := range leases.Items {
+		// the replica itself is alive only if its own lease is not expired
+		if lease.Name == m.identity {
+			continue
+		}
+
+		expireTime, ok := leaseExpireTime(&lease)
+		if !ok {
+			continue
+		}
+		if now.Before(expireTime) {
+			members = append(members, lease.Name)
+			continue
+		}
+
+		// the lease of the dead replica is retained for a while in case the replica is only slow to renew
+		if now.After(expireTime.Add(2 * m.options.LeaseDuration)) {
+			if err := m.client.CoordinationV1().Leases(m.options.Namespace).Delete(ctx, lease.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+				klog.ErrorS(err, "Failed to delete expired shard lease", "lease", lease.Name)
+			}
+		}
+	}
+	return members, nil
+}
+
+func (m *Membership) renew(ctx context.Context) error {
+	leases := m.client.CoordinationV1().Leases(m.options.Namespace)
+	now := metav1.NewMicroTime(time.Now())
+	durationSeconds := int32(m.options.LeaseDuration.Seconds())
+
+	lease, err := leases.Get(ctx, m.identity, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		lease = &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      m.identity,
+				Namespace: m.options.Namespace,
+				Labels:    map[string]string{ShardLabel: ""},
+			},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       &m.identity,
+				LeaseDurationSeconds: &durationSeconds,
+				AcquireTime:          &now,
+				RenewTime:            &now,
+			},
+		}
+		_, err = leases.Create(ctx, lease, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	if lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity != m.identity {
+		return fmt.Errorf("lease %s is held by other replica", lease.Name)
+	}
+	lease.Spec.LeaseDurationSeconds = &durationSeconds
+	lease.Spec.RenewTime = &now
+	_, err = leases.Update(ctx, lease, metav1.UpdateOptions{})
+	return err
+}
+
+func leaseExpireTime(lease *coordinationv1.Lease) (time.Time, bool) {
+	if lease.Spec.RenewTime == nil || lease.Spec.LeaseDurationSeconds == nil {
+		return time.Time{}, false
+	}
+	return lease.Spec.RenewTime.Add(time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second), true
+}
+
+func stringSliceEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,225 @@
+package sharding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+const (
+	testNamespace     = "clusterpedia-system"
+	testIdentity      = "replica-a"
+	testLeaseDuration = 15 * time.Second
+)
+
+func newTestMembership(client *fake.Clientset, onChange func()) *Membership {
+	return NewMembership(client, Options{
+		Enabled:       true,
+		Identity:      testIdentity,
+		Namespace:     testNamespace,
+		LeaseDuration: testLeaseDuration,
+		RenewInterval: 5 * time.Second,
+	}, onChange)
+}
+
+func newTestLease(name, label, holder string, renewTime time.Time) *coordinationv1.Lease {
+	durationSeconds := int32(testLeaseDuration.Seconds())
+	renew := metav1.NewMicroTime(renewTime)
+	lease := &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNamespace,
+			Labels:    map[string]string{label: ""},
+		},
+		Spec: coordinationv1.LeaseSpec{
+			LeaseDurationSeconds: &durationSeconds,
+			RenewTime:            &renew,
+		},
+	}
+	if holder != "" {
+		lease.Spec.HolderIdentity = &holder
+	}
+	return lease
+}
+
+func failLeases(client *fake.Clientset, verb string) {
+	client.PrependReactor(verb, "leases", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("injected " + verb + " failure")
+	})
+}
+
+func TestMembershipOwnerStability(t *testing.T) {
+	tests := []struct {
+		name    string
+		members []string
+		changed []string
+	}{
+		{
+			name:    "member joins",
+			members: []string{"replica-a", "replica-b", "replica-c"},
+			changed: []string{"replica-a", "replica-b", "replica-c", "replica-d"},
+		},
+		{
+			name:    "member leaves",
+			members: []string{"replica-a", "replica-b", "replica-c"},
+			changed: []string{"replica-a", "replica-c"},
+		},
+		{
+			name:    "first member joins",
+			members: nil,
+			changed: []string{"replica-a"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := newTestMembership(fake.NewSimpleClientset(), nil)
+
+			clusters := make([]string, 0, 200)
+			for i := 0; i < 200; i++ {
+				clusters = append(clusters, fmt.Sprintf("cluster-%d", i))
+			}
+
+			m.members = test.members
+			owners := make(map[string]string, len(clusters))
+			for _, cluster := range clusters {
+				owners[cluster] = m.Owner(cluster)
+			}
+
+			m.members = test.changed
+			before, after := make(map[string]bool), make(map[string]bool)
+			for _, member := range test.members {
+				before[member] = true
+			}
+			for _, member := range test.changed {
+				after[member] = true
+			}
+			var moved int
+			counts := make(map[string]int)
+			for _, cluster := range clusters {
+				owner := m.Owner(cluster)
+				counts[owner]++
+				if !after[owner] {
+					t.Fatalf("cluster %s is assigned to %q, which is not a member", cluster, owner)
+				}
+				if got, want := m.Owns(cluster), owner == testIdentity; got != want {
+					t.Errorf("Owns(%s) = %v, want %v", cluster, got, want)
+				}
+				if owner == owners[cluster] {
+					continue
+				}
+				moved++
+
+				// only the clusters of the left member or the clusters taken by the joined member are moved
+				if after[owners[cluster]] && before[owner] {
+					t.Errorf("cluster %s is moved from %q to %q", cluster, owners[cluster], owner)
+				}
+			}
+			if moved == 0 {
+				t.Errorf("no cluster is moved after the members are changed")
+			}
+			for _, member := range test.changed {
+				if counts[member] == 0 {
+					t.Errorf("no cluster is assigned to %q, the clusters are assigned as %v", member, counts)
+				}
+			}
+		})
+	}
+
+	m := newTestMembership(fake.NewSimpleClientset(), nil)
+	if owner := m.Owner("cluster-0"); owner != "" {
+		t.Errorf("Owner() without members = %q, want empty", owner)
+	}
+}
+
+func TestMembershipRefresh(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name         string
+		failUpdate   bool
+		failList     bool
+		lastRenewAge time.Duration
+		heldAge      time.Duration
+		wantMembers  []string
+		wantChange   bool
+	}{
+		{
+			name:        "members are not changed",
+			wantMembers: []string{"replica-a", "replica-b"},
+		},
+		{
+			name:        "members are kept if the list fails",
+			failList:    true,
+			wantMembers: []string{"replica-a", "replica-b"},
+		},
+		{
+			name:         "replica is dropped after its lease is expired even if the list fails",
+			failUpdate:   true,
+			failList:     true,
+			lastRenewAge: 2 * testLeaseDuration,
+			wantMembers:  []string{"replica-b"},
+			wantChange:   true,
+		},
+		{
+			name:         "replica is kept before its lease is expired",
+			failUpdate:   true,
+			lastRenewAge: testLeaseDuration / 3,
+			heldAge:      testLeaseDuration / 3,
+			wantMembers:  []string{"replica-a", "replica-b"},
+		},
+		{
+			name:        "cluster lease is lost while the list fails",
+			failUpdate:  true,
+			failList:    true,
+			heldAge:     2 * testLeaseDuration,
+			wantMembers: []string{"replica-a", "replica-b"},
+			wantChange:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := fake.NewSimpleClientset(
+				newTestLease(testIdentity, ShardLabel, testIdentity, now),
+				newTestLease("replica-b", ShardLabel, "replica-b", now),
+				newTestLease(clusterLeaseName("cluster-1"), ClusterLeaseLabel, testIdentity, now.Add(-test.heldAge)),
+			)
+			var changes int
+			m := newTestMembership(client, func() { changes++ })
+			m.members = []string{"replica-a", "replica-b"}
+			m.synced = true
+			m.lastRenew = now.Add(-test.lastRenewAge)
+			if test.heldAge != 0 {
+				lease, _ := client.CoordinationV1().Leases(testNamespace).Get(context.TODO(), clusterLeaseName("cluster-1"), metav1.GetOptions{})
+				m.holdCluster("cluster-1", lease, now.Add(-test.heldAge))
+			}
+
+			if test.failUpdate {
+				failLeases(client, "update")
+			}
+			if test.failList {
+				failLeases(client, "list")
+			}
+			m.refresh()
+
+			if !reflect.DeepEqual(m.members, test.wantMembers) {
+				t.Errorf("members = %v, want %v", m.members, test.wantMembers)
+			}
+			if got := changes != 0; got != test.wantChange {
+				t.Errorf("onChange is called = %v, want %v", got, test.wantChange)
+			}
+			if !m.HasSynced() {
+				t.Errorf("HasSynced() = false, want true")
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package sharding
+
+import (
+	"time"
+)
+
+const (
+	DefaultNamespace     = "clusterpedia-system"
+	DefaultLeaseDuration = 15 * time.Second
+	DefaultRenewInterval = 5 * time.Second
+)
+
+// Options are the options of the active-active sharding mode,
+// in this mode every replica of the manager synchronizes a shard of the clusters.
+type Options struct {
+	Enabled bool
+
+	// Identity is the unique identity of the replica, it is used as the name of the replica's lease.
+	// If it is empty, it is generated with the hostname.
+	Identity string
+
+	// Namespace is the namespace of the replicas' leases
+	Namespace string
+
+	// LeaseDuration is how long the replica is considered alive after its lease is renewed
+	LeaseDuration time.Duration
+
+	// RenewInterval is the interval of renewing the lease and refreshing the replicas
+	RenewInterval time.Duration
+}
+
+func NewOptions() Options {
+	return Options{
+		Namespace:     DefaultNamespace,
+		LeaseDuration: DefaultLeaseDuration,
+		RenewInterval: DefaultRenewInterval,
+	}
+}
@@ -0,0 +1,152 @@
+package synchromanager
+
+import (
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	clustersv1alpha1 "github.com/clusterpedia-io/clusterpedia/pkg/apis/clusters/v1alpha1"
+)
+
+func (manager *Manager) addSyncResources(obj interface{}) {
+	manager.enqueueClustersForSyncResources(obj)
+}
+
+func (manager *Manager) updateSyncResources(older, newer interface{}) {
+	oldObj := older.(*clustersv1alpha1.ClusterSyncResources)
+	newObj := newer.(*clustersv1alpha1.ClusterSyncResources)
+	if equality.Semantic.DeepEqual(oldObj.Spec, newObj.Spec) && equality.Semantic.DeepEqual(oldObj.Labels, newObj.Labels) {
+		return
+	}
+
+	// the clusters that selected the old labels are also enqueued
+	manager.enqueueClustersForSyncResources(older)
+	manager.enqueueClustersForSyncResources(newer)
+}
+
+func (manager *Manager) deleteSyncResources(obj interface{}) {
+	manager.enqueueClustersForSyncResources(obj)
+}
+
+// enqueueClustersForSyncResources enqueues the clusters that reference the sync resources by name or by label selector
+func (manager *Manager) enqueueClustersForSyncResources(obj interface{}) {
+	if d, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = d.Obj
+	}
+	syncResources, ok := obj.(*clustersv1alpha1.ClusterSyncResources)
+	if !ok {
+		return
+	}
+
+	clusters, err := manager.clusterlister.List(labels.Everything())
+	if err != nil {
+		klog.ErrorS(err, "Failed to list clusters", "syncResources", syncResources.Name)
+		return
+	}
+
+	for _, cluster := range clusters {
+		if !referencesSyncResources(cluster, syncResources) {
+			continue
+		}
+		klog.V(2).InfoS("cluster sync resources is changed", "cluster", cluster.Name, "syncResources", syncResources.Name)
+		manager.enqueue(cluster)
+	}
+}
+
+func referencesSyncResources(cluster *clustersv1alpha1.PediaCluster, syncResources *clustersv1alpha1.ClusterSyncResources) bool {
+	if cluster.Spec.SyncResourcesRefName == syncResources.Name {
+		return true
+	}
+	if cluster.Spec.SyncResourcesSelector == nil {
+		return false
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(cluster.Spec.SyncResourcesSelector)
+	if err != nil {
+		return false
+	}
+	return selector.Matches(labels.Set(syncResources.Labels))
+}
+
+// resolveClusterResources merges the inline resources of the cluster with the resources of the referenced sync resources,
+// the inline resources and the resources referenced by name take precedence over the selected ones.
+func (manager *Manager) resolveClusterResources(cluster *clustersv1alpha1.PediaCluster) ([]clustersv1alpha1.ClusterResource, error) {
+	if cluster.Spec.SyncResourcesRefName == "" && cluster.Spec.SyncResourcesSelector == nil {
+		return cluster.Spec.Resources, nil
+	}
+
+	var referenced []*clustersv1alpha1.ClusterSyncResources
+	if name := cluster.Spec.SyncResourcesRefName; name != "" {
+		syncResources, err := manager.syncResourcesLister.Get(name)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to get cluster sync resources %s: %w", name, err)
+		}
+		referenced = append(referenced, syncResources)
+	}
+
+	if cluster.Spec.SyncResourcesSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(cluster.Spec.SyncResourcesSelector)
+		if err != nil {
+			return nil, fmt.Errorf("Cluster sync resources selector is invalid: %w", err)
+		}
+
+		selected, err := manager.syncResourcesLister.List(selector)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to list cluster sync resources: %w", err)
+		}
+		sort.Slice(selected, func(i, j int) bool {
+			return selected[i].Name < selected[j].Name
+		})
+		for _, syncResources := range selected {
+			if syncResources.Name != cluster.Spec.SyncResourcesRefName {
+				referenced = append(referenced, syncResources)
+			}
+		}
+	}
+
+	resources := mergeClusterResources(cluster.Spec.Resources, nil)
+	for _, syncResources := range referenced {
+		resources = mergeClusterResources(resources, syncResources.Spec.SyncResources)
+	}
+	return resources, nil
+}
+
+// mergeClusterResources appends the additional resources that are not in the resources,
+// the wildcard resources are always appended.
+func mergeClusterResources(resources, additional []clustersv1alpha1.ClusterResource) []clustersv1alpha1.ClusterResource {
+	merged := make([]clustersv1alpha1.ClusterResource, 0, len(resources)+len(additional))
+	existed := sets.NewString()
+	for _, resource := range resources {
+		for _, name := range resource.Resources {
+			existed.Insert(schema.GroupResource{Group: resource.Group, Resource: name}.String())
+		}
+		merged = append(merged, resource)
+	}
+
+	for _, resource := range additional {
+		var names []string
+		for _, name := range resource.Resources {
+			gr := schema.GroupResource{Group: resource.Group, Resource: name}.String()
+			if name != clustersv1alpha1.ResourceWildcard && existed.Has(gr) {
+				continue
+			}
+			existed.Insert(gr)
+			names = append(names, name)
+		}
+		if len(names) == 0 {
+			continue
+		}
+
+		resource = *resource.DeepCopy()
+		resource.Resources = names
+		merged = append(merged, resource)
+	}
+	return merged
+}